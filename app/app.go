@@ -2,6 +2,7 @@ package app
 
 import (
 	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/internal/tui"
 	"github.com/spf13/cobra"
 )
 
@@ -12,10 +13,14 @@ const (
 )
 
 func RootCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "pm",
 		Short: "gh pm [command]",
 		Long:  "A Github CLI Extension for managing projects",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			setupLogging(cmd)
+			preflight(cmd)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			c, err := ctx.Get(cmd)
 			if err != nil {
@@ -29,4 +34,9 @@ func RootCmd() *cobra.Command {
 			cmd.Help()
 		},
 	}
+	cmd.PersistentFlags().Bool(tui.NoTUIFlag, false, "Print plain output instead of starting interactive views and forms")
+	cmd.PersistentFlags().Bool("verbose", false, "Log API requests, their rate limit cost and retries to stderr")
+	cmd.PersistentFlags().Bool("debug", false, "Log every gh command and GraphQL query with its arguments, latency and cost (or set "+DebugEnv+"=1)")
+	cmd.PersistentFlags().String("log-file", "", "Write the --verbose and --debug logs to this file (default stderr, or debug.log in the cache directory while a terminal view runs)")
+	return cmd
 }
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// DebugEnv turns on debug logging like --debug when set to anything but 0 or false
+const DebugEnv = "GH_PM_DEBUG"
+
+// logFileName is the log written next to the cache while a terminal view runs
+const logFileName = "debug.log"
+
+// setupLogging sends the request logs of --verbose and --debug to stderr, or
+// to a file when the command may start a terminal view that stderr would draw
+// over
+func setupLogging(cmd *cobra.Command) {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	debug, _ := cmd.Flags().GetBool("debug")
+	if env := strings.ToLower(os.Getenv(DebugEnv)); env != "" && env != "0" && env != "false" {
+		debug = true
+	}
+	if !verbose && !debug {
+		return
+	}
+
+	path, _ := cmd.Flags().GetString("log-file")
+	if path == "" && !tui.IsInteractive(cmd) {
+		ghc.SetLogger(log.New(os.Stderr, "gh-pm: ", log.Ltime), debug)
+		return
+	}
+	if path == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		path = filepath.Join(dir, "gh-pm", logFileName)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		clierr.Exit(err)
+	}
+	l := log.New(io.Discard, "", log.Ldate|log.Lmicroseconds)
+	// The file stays open until the command exits
+	if _, err := tea.LogToFileWith(path, "gh-pm", l); err != nil {
+		clierr.Exit(err)
+	}
+	l.Printf("gh pm %s", strings.Join(os.Args[1:], " "))
+	fmt.Fprintf(os.Stderr, "Logging requests to %s\n", path)
+	ghc.SetLogger(l, debug)
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// preflight checks that the token has the scope cmd needs before it runs, so
+// a missing scope is reported up front instead of as a GraphQL error. In a
+// terminal it offers to add the scope with gh auth refresh.
+func preflight(cmd *cobra.Command) {
+	scope := ctx.RequiredScope(cmd)
+	if scope == "" {
+		return
+	}
+	if cached, err := cmd.Flags().GetBool("cached"); err == nil && cached {
+		return
+	}
+	// A dry run only reads the project
+	if dryRun, err := cmd.Flags().GetBool("dry-run"); err == nil && dryRun && scope == ctx.ScopeProject {
+		scope = ctx.ScopeReadProject
+	}
+	auth, err := ctx.GetAuth()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if auth.HasScope(scope) {
+		return
+	}
+	if !tui.IsInteractive(cmd) {
+		clierr.Exit(ctx.MissingScopeError(scope))
+	}
+
+	refresh := false
+	err = huh.NewConfirm().
+		Title(fmt.Sprintf("Your token is missing the %s scope. Add it now?", scope)).
+		Description("This runs gh auth refresh --scopes project, which asks you to sign in again in the browser.").
+		Value(&refresh).
+		Run()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if !refresh {
+		clierr.Exit(ctx.MissingScopeError(scope))
+	}
+	if err := ctx.RefreshScopes(); err != nil {
+		clierr.Exit(clierr.New(clierr.KindAuth, fmt.Errorf("refreshing the token: %w", err), clierr.ScopeHint))
+	}
+	if auth, err = ctx.GetAuth(); err != nil {
+		clierr.Exit(err)
+	}
+	if !auth.HasScope(scope) {
+		clierr.Exit(ctx.MissingScopeError(scope))
+	}
+	fmt.Fprintln(os.Stderr, "Added the project scope to your token")
+}
@@ -0,0 +1,168 @@
+// Package clierr classifies the errors gh-pm reports to users, attaches a hint
+// on how to fix them and maps them to exit codes.
+package clierr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Kind tells what went wrong from the user's point of view
+type Kind int
+
+const (
+	// KindUnknown is an unexpected failure
+	KindUnknown Kind = iota
+	// KindValidation is invalid input such as a flag, an argument or a file
+	KindValidation
+	// KindNotFound is a project, field, item or repository that does not
+	// exist or cannot be seen by the viewer
+	KindNotFound
+	// KindAuth is a missing login or a token lacking a required scope
+	KindAuth
+	// KindRateLimit is a request rejected by a GitHub rate limit
+	KindRateLimit
+	// KindNetwork is GitHub being unreachable
+	KindNetwork
+	// KindCancelled is the user interrupting the command
+	KindCancelled
+)
+
+// Exit codes returned by gh-pm, one per kind of error
+const (
+	ExitOK         = 0
+	ExitError      = 1
+	ExitValidation = 2
+	ExitNotFound   = 3
+	ExitAuth       = 4
+	ExitRateLimit  = 5
+	ExitNetwork    = 6
+	ExitCancelled  = 130
+)
+
+// ExitCode returns the exit code of the kind
+func (k Kind) ExitCode() int {
+	switch k {
+	case KindValidation:
+		return ExitValidation
+	case KindNotFound:
+		return ExitNotFound
+	case KindAuth:
+		return ExitAuth
+	case KindRateLimit:
+		return ExitRateLimit
+	case KindNetwork:
+		return ExitNetwork
+	case KindCancelled:
+		return ExitCancelled
+	}
+	return ExitError
+}
+
+// Error is an error of a known kind with an optional hint telling the user
+// how to fix it
+type Error struct {
+	Kind Kind
+	Err  error
+	Hint string
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns an error of kind with a hint, which may be empty
+func New(kind Kind, err error, hint string) *Error {
+	return &Error{Kind: kind, Err: err, Hint: hint}
+}
+
+// Invalidf formats an error about invalid input; %w wraps errors as in fmt.Errorf
+func Invalidf(format string, args ...any) error {
+	return &Error{Kind: KindValidation, Err: fmt.Errorf(format, args...)}
+}
+
+// NotFoundf formats an error about something that does not exist
+func NotFoundf(format string, args ...any) error {
+	return &Error{Kind: KindNotFound, Err: fmt.Errorf(format, args...)}
+}
+
+// WithHint returns err with hint attached, keeping its kind
+func WithHint(err error, hint string) error {
+	e := Classify(err)
+	return &Error{Kind: e.Kind, Err: err, Hint: hint}
+}
+
+// Classify returns the first *Error wrapped by err. Errors of an unknown kind
+// are recognized as cancellations, including aborted forms, or network
+// failures where possible.
+func Classify(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, huh.ErrUserAborted):
+		return &Error{Kind: KindCancelled, Err: err}
+	case errors.As(err, &dnsErr), errors.As(err, &opErr), errors.As(err, &netErr) && netErr.Timeout():
+		return &Error{Kind: KindNetwork, Err: err, Hint: NetworkHint}
+	}
+	return &Error{Kind: KindUnknown, Err: err}
+}
+
+// Hints shared by the places that classify errors
+const (
+	// ScopeHint fixes a token without the project scope
+	ScopeHint = "run `gh auth refresh -s project` to grant gh access to projects"
+	// LoginHint fixes a missing or expired login
+	LoginHint = "run `gh auth login` to sign in to GitHub"
+	// NetworkHint suggests checking the connection
+	NetworkHint = "check your network connection, or https://www.githubstatus.com for an outage"
+	// RateLimitHint suggests waiting for the budget to reset
+	RateLimitHint = "wait for the rate limit to reset, or set GH_PM_WORKERS=1 to send fewer requests at once"
+)
+
+var hintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+// Render formats err for display: the message followed by its hint
+func Render(err error) string {
+	e := Classify(err)
+	s := err.Error()
+	if e.Hint != "" {
+		s += "\n" + hintStyle.Render("Hint: "+e.Hint)
+	}
+	return s
+}
+
+// Print writes err and its hint to w, prefixed with "Error: "
+func Print(w io.Writer, err error) {
+	e := Classify(err)
+	if e.Kind == KindCancelled {
+		fmt.Fprintln(w, "Cancelled")
+		return
+	}
+	fmt.Fprintf(w, "Error: %v\n", err)
+	if e.Hint != "" {
+		fmt.Fprintf(w, "Hint: %s\n", strings.TrimSpace(e.Hint))
+	}
+}
+
+// Exit prints err to stderr and exits with the code of its kind
+func Exit(err error) {
+	Print(os.Stderr, err)
+	os.Exit(Classify(err).Kind.ExitCode())
+}
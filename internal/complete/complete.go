@@ -0,0 +1,311 @@
+// Package complete provides the dynamic shell completions of gh pm. They read
+// the local cache filled by `gh pm project sync` so that they answer at once,
+// and only ask the API, with a short timeout, for what the cache lacks.
+package complete
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// apiTimeout bounds the API requests made when the cache lacks the answer, so
+// that a slow network does not hang the shell
+const apiTimeout = 3 * time.Second
+
+const noFiles = cobra.ShellCompDirectiveNoFileComp
+
+// Owners completes --owner with the viewer, then their organizations and the
+// owners of cached projects
+func Owners(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	viewer, orgs := "", ""
+	var others []string
+	if s, ok := openCache(); ok {
+		viewer, _ = s.Meta(store.MetaViewer)
+		orgs, _ = s.Meta(store.MetaOrgs)
+		projects, _ := s.Projects()
+		for _, p := range projects {
+			others = append(others, p.Owner)
+		}
+		s.Close()
+	}
+	if orgs == "" {
+		orgs = fetchOrgs(cmd)
+	}
+	if orgs != "" {
+		others = append(others, strings.Split(orgs, ",")...)
+	}
+	slices.SortFunc(others, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	owners := []string{"@me"}
+	if viewer != "" {
+		owners = []string{viewer}
+	}
+	for _, owner := range others {
+		if !slices.ContainsFunc(owners, func(o string) bool { return strings.EqualFold(o, owner) }) {
+			owners = append(owners, owner)
+		}
+	}
+	return owners, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// fetchOrgs asks the API for the organizations of the viewer and remembers
+// them in the cache, as they rarely change. It returns "" when the API does
+// not answer within apiTimeout.
+func fetchOrgs(cmd *cobra.Command) string {
+	found := make(chan []string, 1)
+	go func() {
+		list, _ := ctx.Orgs(cmd)
+		found <- list
+	}()
+	var list []string
+	select {
+	case list = <-found:
+	case <-time.After(apiTimeout):
+		return ""
+	}
+	orgs := strings.Join(list, ",")
+	if s, ok := openCache(); ok && orgs != "" {
+		s.SetMeta(store.MetaOrgs, orgs)
+		s.Close()
+	}
+	return orgs
+}
+
+// Projects completes a project number, as the first argument or the value of
+// --project, with the projects of --owner described by their titles
+func Projects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	owner := ownerFlag(cmd)
+	var list []string
+	if s, ok := openCache(); ok {
+		viewer, _ := s.Meta(store.MetaViewer)
+		projects, _ := s.Projects()
+		s.Close()
+		for _, p := range projects {
+			if strings.EqualFold(p.Owner, owner) || (owner == "" && strings.EqualFold(p.Owner, viewer)) {
+				list = append(list, describe(strconv.Itoa(p.Number), p.Title, p.Closed))
+			}
+		}
+	}
+	if len(list) > 0 {
+		return list, noFiles
+	}
+
+	reqCtx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+	projects, err := ghc.GetOwnerProjectsContext(reqCtx, owner)
+	if err != nil {
+		return nil, noFiles
+	}
+	for _, p := range projects {
+		list = append(list, describe(strconv.Itoa(int(p.Number)), p.Title, p.Closed))
+	}
+	return list, noFiles
+}
+
+// ProjectArg completes the project number of commands taking it as their only
+// argument
+func ProjectArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, noFiles
+	}
+	return Projects(cmd, args, toComplete)
+}
+
+// ProjectThenItems completes a project number followed by its items
+func ProjectThenItems(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return Projects(cmd, args, toComplete)
+	}
+	return Items(cmd, args, toComplete)
+}
+
+// ProjectThenDraft completes a project number followed by one of its drafts
+func ProjectThenDraft(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return Projects(cmd, args, toComplete)
+	case 1:
+		return drafts(cmd, args)
+	}
+	return nil, noFiles
+}
+
+// ProjectThenField completes a project number followed by one of its fields
+func ProjectThenField(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return Projects(cmd, args, toComplete)
+	case 1:
+		project, _, ok := cachedProject(cmd, args)
+		if !ok {
+			return nil, noFiles
+		}
+		var list []string
+		for _, f := range project.Fields {
+			list = append(list, f.Name)
+		}
+		return list, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	}
+	return nil, noFiles
+}
+
+// Items completes the issues and pull requests of the cached project, as
+// owner/repo#12 with their titles, leaving out those already given
+func Items(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	_, items, ok := cachedProject(cmd, args)
+	if !ok {
+		return nil, noFiles
+	}
+	var list []string
+	for _, item := range items {
+		if item.Type == models.ItemTypeDraftIssue || slices.Contains(args[1:], item.Ref()) {
+			continue
+		}
+		list = append(list, describe(item.Ref(), item.Title, false))
+	}
+	return list, noFiles
+}
+
+// drafts completes the titles of the draft issues of the cached project
+func drafts(cmd *cobra.Command, args []string) ([]string, cobra.ShellCompDirective) {
+	_, items, ok := cachedProject(cmd, args)
+	if !ok {
+		return nil, noFiles
+	}
+	var list []string
+	for _, item := range items {
+		if item.Type == models.ItemTypeDraftIssue {
+			list = append(list, item.Title)
+		}
+	}
+	return list, noFiles
+}
+
+// Statuses completes --status with the Status options of the project given
+// as the first argument or with --project
+func Statuses(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	project, _, ok := cachedProject(cmd, args)
+	if !ok {
+		owner, number := ownerFlag(cmd), projectNumber(cmd, args)
+		if number == 0 {
+			return nil, noFiles
+		}
+		reqCtx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+		defer cancel()
+		var err error
+		if project, err = ghc.GetProjectContext(reqCtx, owner, number); err != nil {
+			return nil, noFiles
+		}
+	}
+	field := project.Field(models.StatusField)
+	if field == nil {
+		return nil, noFiles
+	}
+	var list []string
+	for _, o := range field.Options {
+		list = append(list, describe(o.Name, o.Description, false))
+	}
+	return list, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// cachedProject reads the project named by the arguments and flags of cmd, and
+// its items, from the cache
+func cachedProject(cmd *cobra.Command, args []string) (*models.Project, []models.Item, bool) {
+	number := projectNumber(cmd, args)
+	if number == 0 {
+		return nil, nil, false
+	}
+	s, ok := openCache()
+	if !ok {
+		return nil, nil, false
+	}
+	defer s.Close()
+	owner := ownerFlag(cmd)
+	if owner == "" {
+		if owner, _ = s.Meta(store.MetaViewer); owner == "" {
+			return nil, nil, false
+		}
+	}
+	project, err := s.Project(owner, number)
+	if err != nil {
+		return nil, nil, false
+	}
+	items, err := s.Items(project.ID, "")
+	if err != nil {
+		return nil, nil, false
+	}
+	return project, items, true
+}
+
+// openCache opens the cache if it was created
+func openCache() (*store.Store, bool) {
+	if !store.Exists() {
+		return nil, false
+	}
+	s, err := store.Open()
+	return s, err == nil
+}
+
+// ownerFlag returns the value of --owner, "" for the viewer
+func ownerFlag(cmd *cobra.Command) string {
+	owner, _ := cmd.Flags().GetString("owner")
+	if owner == "@me" {
+		return ""
+	}
+	return owner
+}
+
+// projectNumber returns the project number given as the first argument or
+// with --project, or 0
+func projectNumber(cmd *cobra.Command, args []string) int {
+	if number, err := cmd.Flags().GetInt("project"); err == nil && number > 0 {
+		return number
+	}
+	if len(args) > 0 {
+		number, _ := strconv.Atoi(args[0])
+		return number
+	}
+	return 0
+}
+
+// describe returns a completion with a description shown by the shells that
+// support them
+func describe(value, description string, closed bool) string {
+	if closed {
+		description += " (closed)"
+	}
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return value
+	}
+	return value + "\t" + description
+}
+
+// RegisterFlags completes --owner, --project and --status on cmd and the
+// commands under it that define them
+func RegisterFlags(cmd *cobra.Command) {
+	funcs := map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+		"owner":   Owners,
+		"project": Projects,
+		"status":  Statuses,
+	}
+	for name, fn := range funcs {
+		if cmd.LocalFlags().Lookup(name) != nil {
+			cmd.RegisterFlagCompletionFunc(name, fn)
+		}
+	}
+	for _, c := range cmd.Commands() {
+		RegisterFlags(c)
+	}
+}
@@ -0,0 +1,184 @@
+// Package config reads and writes the user's gh-pm configuration file
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const fileName = "config.yml"
+
+// Config is the content of the configuration file
+type Config struct {
+	// Views are saved project views, opened with `gh pm view open <name>`
+	Views []View `yaml:"views,omitempty"`
+	// Sprint configures the sprint commands
+	Sprint Sprint `yaml:"sprint,omitempty"`
+	// Mine configures `gh pm mine`
+	Mine Mine `yaml:"mine,omitempty"`
+	// Workers is the number of API requests run at the same time when loading
+	// many projects or updating many items, see Concurrency
+	Workers int `yaml:"workers,omitempty"`
+}
+
+// WorkersEnv overrides the workers setting of the configuration file
+const WorkersEnv = "GH_PM_WORKERS"
+
+// Concurrency returns the number of API requests to run at the same time:
+// GH_PM_WORKERS when set, then the workers setting. Zero leaves the choice to
+// the caller.
+func (c *Config) Concurrency() int {
+	if n, err := strconv.Atoi(os.Getenv(WorkersEnv)); err == nil && n > 0 {
+		return n
+	}
+	return c.Workers
+}
+
+// Sprint names the project fields the sprint commands work with
+type Sprint struct {
+	// Field is the iteration field holding sprints, the first iteration field when empty
+	Field string `yaml:"field,omitempty"`
+	// Points is a number field summed as story points in sprint summaries
+	Points string `yaml:"points,omitempty"`
+	// Done lists the Status options that count as finished, "Done" when empty
+	Done []string `yaml:"done,omitempty"`
+}
+
+// Mine names the project fields that rank the items listed by `gh pm mine`
+type Mine struct {
+	// Priority is a single select or number field, "Priority" when empty
+	Priority string `yaml:"priority,omitempty"`
+	// Due is a date field, the first date field with "due" in its name when empty
+	Due string `yaml:"due,omitempty"`
+}
+
+// View is a named project layout with its filter, columns and sort order
+type View struct {
+	Name    string   `yaml:"name"`
+	Owner   string   `yaml:"owner,omitempty"`
+	Project int      `yaml:"project"`
+	Layout  string   `yaml:"layout,omitempty"`
+	Columns []string `yaml:"columns,omitempty"`
+	Sort    string   `yaml:"sort,omitempty"`
+	Group   string   `yaml:"group,omitempty"`
+	Filter  string   `yaml:"filter,omitempty"`
+}
+
+// Path returns the location of the configuration file
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-pm", fileName), nil
+}
+
+// Load reads the configuration file; a missing file yields an empty configuration
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the configuration file, creating its directory if needed
+func (c *Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// View returns the saved view with the given case-insensitive name
+func (c *Config) View(name string) (View, bool) {
+	i := c.viewIndex(name)
+	if i < 0 {
+		return View{}, false
+	}
+	return c.Views[i], true
+}
+
+// SetView adds v, replacing a saved view of the same name
+func (c *Config) SetView(v View) {
+	if i := c.viewIndex(v.Name); i >= 0 {
+		c.Views[i] = v
+		return
+	}
+	c.Views = append(c.Views, v)
+}
+
+// DeleteView removes the named view and reports whether it existed
+func (c *Config) DeleteView(name string) bool {
+	i := c.viewIndex(name)
+	if i < 0 {
+		return false
+	}
+	c.Views = slices.Delete(c.Views, i, i+1)
+	return true
+}
+
+func (c *Config) viewIndex(name string) int {
+	return slices.IndexFunc(c.Views, func(v View) bool {
+		return strings.EqualFold(v.Name, name)
+	})
+}
+
+// Validate reports the settings that commands would reject or ignore, such as
+// saved views without a project or an invalid GH_PM_WORKERS
+func (c *Config) Validate() error {
+	var errs []error
+	seen := map[string]bool{}
+	for i, v := range c.Views {
+		name := strings.ToLower(v.Name)
+		switch {
+		case v.Name == "":
+			errs = append(errs, fmt.Errorf("view %d has no name", i+1))
+		case seen[name]:
+			errs = append(errs, fmt.Errorf("view %q is defined twice", v.Name))
+		}
+		seen[name] = true
+		if v.Project < 1 {
+			errs = append(errs, fmt.Errorf("view %q has no project number", v.Name))
+		}
+		if v.Layout != "" && v.Layout != "board" && v.Layout != "table" {
+			errs = append(errs, fmt.Errorf("view %q has layout %q, expected board or table", v.Name, v.Layout))
+		}
+	}
+	if c.Workers < 0 {
+		errs = append(errs, fmt.Errorf("workers is %d, expected a positive number", c.Workers))
+	}
+	if env := os.Getenv(WorkersEnv); env != "" {
+		if n, err := strconv.Atoi(env); err != nil || n < 1 {
+			errs = append(errs, fmt.Errorf("%s is %q, expected a positive number", WorkersEnv, env))
+		}
+	}
+	return errors.Join(errs...)
+}
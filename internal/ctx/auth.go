@@ -0,0 +1,107 @@
+package ctx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/spf13/cobra"
+)
+
+// ScopeAnnotation marks a command, and the commands under it, as needing the
+// token scope it is set to before running
+const ScopeAnnotation = "gh-pm/scope"
+
+// Token scopes granting access to projects
+const (
+	ScopeProject     = "project"
+	ScopeReadProject = "read:project"
+)
+
+// Auth is the login and token scopes of the authenticated user
+type Auth struct {
+	Login string
+	// Scopes are the OAuth scopes of the token; nil when the token does not
+	// report them, as fine-grained and GitHub App tokens do not
+	Scopes []string
+}
+
+// HasScope reports whether the token grants scope. The project scope implies
+// read:project. Tokens that do not report scopes are assumed to have them.
+func (a Auth) HasScope(scope string) bool {
+	if a.Scopes == nil {
+		return true
+	}
+	if slices.Contains(a.Scopes, scope) {
+		return true
+	}
+	return scope == ScopeReadProject && slices.Contains(a.Scopes, ScopeProject)
+}
+
+// GetAuth asks the API who the token belongs to and which scopes it has
+func GetAuth() (Auth, error) {
+	client, err := gh.RESTClient(nil)
+	if err != nil {
+		return Auth{}, clierr.New(clierr.KindAuth, err, clierr.LoginHint)
+	}
+	resp, err := client.Request(http.MethodGet, "user", nil)
+	if err != nil {
+		var httpErr api.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized {
+			return Auth{}, clierr.New(clierr.KindAuth, err, clierr.LoginHint)
+		}
+		return Auth{}, clierr.Classify(err)
+	}
+	defer resp.Body.Close()
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return Auth{}, err
+	}
+	auth := Auth{Login: user.Login}
+	if header, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		auth.Scopes = []string{}
+		for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				auth.Scopes = append(auth.Scopes, scope)
+			}
+		}
+	}
+	return auth, nil
+}
+
+// RequiredScope returns the scope cmd needs, set with ScopeAnnotation on cmd
+// or one of its parents, or "" when it needs none
+func RequiredScope(cmd *cobra.Command) string {
+	for c := cmd; c != nil; c = c.Parent() {
+		if scope, ok := c.Annotations[ScopeAnnotation]; ok {
+			return scope
+		}
+	}
+	return ""
+}
+
+// MissingScopeError returns the error of a token lacking scope
+func MissingScopeError(scope string) error {
+	return clierr.New(clierr.KindAuth,
+		errors.New("your token is missing the "+scope+" scope needed to access projects"),
+		clierr.ScopeHint)
+}
+
+// RefreshScopes runs gh auth refresh in the terminal to add the project scope
+// to the token. Its output goes to stderr, keeping stdout for the report of
+// the command, e.g. doctor --fix --json.
+func RefreshScopes() error {
+	cmd := exec.Command("gh", "auth", "refresh", "--scopes", ScopeProject)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stderr, os.Stderr
+	return cmd.Run()
+}
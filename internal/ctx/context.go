@@ -36,6 +36,17 @@ func (c *Current) String() string {
 	return fmt.Sprintf("Current{RepoName: %v, RepoOwner: %v, Branch: %v, Path: %v}", c.RepoName, c.RepoOwner, c.Branch, c.Path)
 }
 
+// Orgs returns the organizations of the viewer, reusing the context of cmd
+// when it was already loaded. Unlike Get it works outside a repository.
+func Orgs(cmd *cobra.Command) ([]string, error) {
+	if cmdCtx := cmd.Context(); cmdCtx != nil {
+		if existingCtx, ok := cmdCtx.Value(ctxKey).(*Context); ok {
+			return existingCtx.Orgs, nil
+		}
+	}
+	return listOrgs()
+}
+
 func Get(cmd *cobra.Command) (*Context, error) {
 	// Try to retrieve existing context
 	cmdCtx := cmd.Context()
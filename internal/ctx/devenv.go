@@ -2,8 +2,11 @@ package ctx
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -34,3 +37,62 @@ func CurrentBranch() (string, error) {
 	branchName := strings.TrimSpace(out.String())
 	return branchName, nil
 }
+
+// MinGHVersion is the oldest gh release with the project commands gh pm runs
+const MinGHVersion = "2.31.0"
+
+// GHVersion returns the version of the gh executable, e.g. "2.40.1"
+func GHVersion() (string, error) {
+	out, err := exec.Command("gh", "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	// gh version 2.40.1 (2023-12-13)
+	fields := strings.Fields(string(out))
+	if len(fields) < 3 || fields[0] != "gh" || fields[1] != "version" {
+		return "", fmt.Errorf("unexpected output of gh --version: %q", strings.TrimSpace(string(out)))
+	}
+	return fields[2], nil
+}
+
+// CompareVersions compares two dotted versions such as 2.31.0, returning a
+// negative number, zero or a positive number when a is older, the same or
+// newer than b
+func CompareVersions(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(pa), len(pb)); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(strings.TrimLeft(pa[i], "v"))
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(strings.TrimLeft(pb[i], "v"))
+		}
+		if na != nb {
+			return na - nb
+		}
+	}
+	return 0
+}
+
+// MissingExtensions returns the gh extensions gh pm relies on that are not
+// installed
+func MissingExtensions() ([]string, error) {
+	out, err := exec.Command("gh", "extension", "list").Output()
+	if err != nil {
+		return nil, err
+	}
+	installed := strings.ToLower(string(out))
+	var missing []string
+	for _, dep := range ghcliExtensionDeps {
+		if !strings.Contains(installed, strings.ToLower(dep)) {
+			missing = append(missing, dep)
+		}
+	}
+	return missing, nil
+}
+
+// ExtensionDeps returns the gh extensions gh pm relies on
+func ExtensionDeps() []string {
+	return slices.Clone(ghcliExtensionDeps)
+}
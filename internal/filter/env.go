@@ -0,0 +1,111 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// Env supplies what is needed to resolve special values in a query
+type Env struct {
+	// Viewer is the login that @me stands for
+	Viewer string
+	// Project provides field types and iterations; it may be nil
+	Project *models.Project
+	// Now is the reference time for relative values, time.Now() when zero
+	Now time.Time
+}
+
+func (e Env) now() time.Time {
+	if e.Now.IsZero() {
+		return time.Now()
+	}
+	return e.Now
+}
+
+// User resolves @me to the viewer login
+func (e Env) User(v string) string {
+	if strings.EqualFold(v, "@me") {
+		return e.Viewer
+	}
+	return v
+}
+
+// Time resolves a date (2006-01-02), @today, @today-7d or a relative time such as 7d, 2w or 12h
+func (e Env) Time(v string) (time.Time, error) {
+	now := e.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if strings.HasPrefix(strings.ToLower(v), "@today") {
+		rest := v[len("@today"):]
+		if rest == "" {
+			return today, nil
+		}
+		if !strings.HasPrefix(rest, "-") {
+			return time.Time{}, fmt.Errorf("invalid date %q, expected @today or @today-<n>d", v)
+		}
+		d, err := parseDuration(rest[1:])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return today.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, v, now.Location()); err == nil {
+		return t, nil
+	}
+	d, err := parseDuration(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(-d), nil
+}
+
+// parseDuration parses a number followed by h (hours), d (days) or w (weeks)
+func parseDuration(v string) (time.Duration, error) {
+	if len(v) < 2 {
+		return 0, fmt.Errorf("invalid date %q, expected 2006-01-02 or a duration such as 7d", v)
+	}
+	n, err := strconv.Atoi(v[:len(v)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid date %q, expected 2006-01-02 or a duration such as 7d", v)
+	}
+	switch v[len(v)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid date %q, expected 2006-01-02 or a duration such as 7d", v)
+}
+
+// Iteration resolves @current, @next and @previous to an iteration title of field
+func (e Env) Iteration(field *models.Field, v string) string {
+	offsets := map[string]int{"@previous": -1, "@current": 0, "@next": 1}
+	offset, ok := offsets[strings.ToLower(v)]
+	if !ok || field == nil {
+		return v
+	}
+	it, ok := field.IterationAt(e.now(), offset)
+	if !ok {
+		// Matches no item rather than every item
+		return "\x00"
+	}
+	return it.Title
+}
+
+// Field returns the project field with the given case-insensitive name
+func (e Env) Field(name string) *models.Field {
+	if e.Project == nil {
+		return nil
+	}
+	for i := range e.Project.Fields {
+		if strings.EqualFold(e.Project.Fields[i].Name, name) {
+			return &e.Project.Fields[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+// Package filter parses the item query language shared by --filter flags and
+// the TUI filter prompt, for example:
+//
+//	is:open assignee:@me status:Todo -label:wontfix iteration:@current updated:>7d
+//
+// A query is a list of space separated terms that must all match. A term is a
+// bare word matched against the title, or a qualifier key:value where value
+// may be quoted and may list alternatives separated by commas. A leading "-"
+// negates a term. Values of number and date qualifiers may start with one of
+// the comparison operators >, >=, < or <=.
+//
+// Special values are @me for the viewer, @current, @next and @previous for
+// iterations, @today for the current day, and relative times such as 7d, 2w or
+// 12h, which stand for that long ago: updated:>7d matches items updated in the
+// last week.
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Qualifiers with a fixed meaning; any other key names a project field
+const (
+	KeyText      = ""
+	KeyIs        = "is"
+	KeyNo        = "no"
+	KeyHas       = "has"
+	KeyAssignee  = "assignee"
+	KeyLabel     = "label"
+	KeyMilestone = "milestone"
+	KeyRepo      = "repo"
+	KeyTitle     = "title"
+	KeyUpdated   = "updated"
+)
+
+// Values accepted by the is: qualifier
+var isValues = []string{"open", "closed", "merged", "draft", "issue", "pr", "archived"}
+
+// Query is a parsed filter; the zero value matches every item
+type Query struct {
+	Terms []Term
+}
+
+// Term is a single condition of a query
+type Term struct {
+	// Negate inverts the condition
+	Negate bool
+	// Key is the lower-cased qualifier, or KeyText for a bare word
+	Key string
+	// Op is a comparison operator, or "" for equality
+	Op string
+	// Values are alternatives of which any may match
+	Values []string
+}
+
+// Parse parses a query string
+func Parse(s string) (Query, error) {
+	words, err := split(s)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	for _, w := range words {
+		var t Term
+		if strings.HasPrefix(w, "-") && len(w) > 1 {
+			t.Negate = true
+			w = w[1:]
+		}
+		key, value, ok := strings.Cut(w, ":")
+		if !ok {
+			t.Values = []string{w}
+			q.Terms = append(q.Terms, t)
+			continue
+		}
+
+		t.Key = strings.ToLower(key)
+		if t.Key == "" {
+			return Query{}, fmt.Errorf("missing qualifier before %q", ":"+value)
+		}
+		for _, op := range []string{">=", "<=", ">", "<"} {
+			if strings.HasPrefix(value, op) {
+				t.Op = op
+				value = value[len(op):]
+				break
+			}
+		}
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				t.Values = append(t.Values, v)
+			}
+		}
+		if len(t.Values) == 0 {
+			return Query{}, fmt.Errorf("missing value for %q", key+":")
+		}
+		if t.Op != "" && len(t.Values) > 1 {
+			return Query{}, fmt.Errorf("comparison %s%s takes a single value", key, t.Op)
+		}
+		if t.Key == KeyUpdated {
+			for _, v := range t.Values {
+				if _, err := (Env{}).Time(v); err != nil {
+					return Query{}, err
+				}
+			}
+		}
+		if t.Key == KeyIs {
+			for _, v := range t.Values {
+				if !slices.Contains(isValues, strings.ToLower(v)) {
+					return Query{}, fmt.Errorf("unknown value %q for is:, expected one of %s", v, strings.Join(isValues, ", "))
+				}
+			}
+		}
+		q.Terms = append(q.Terms, t)
+	}
+	return q, nil
+}
+
+// Empty reports whether the query has no terms
+func (q Query) Empty() bool {
+	return len(q.Terms) == 0
+}
+
+// String formats the query back into its textual form
+func (q Query) String() string {
+	words := make([]string, 0, len(q.Terms))
+	for _, t := range q.Terms {
+		words = append(words, t.String())
+	}
+	return strings.Join(words, " ")
+}
+
+// String formats the term back into its textual form
+func (t Term) String() string {
+	var sb strings.Builder
+	if t.Negate {
+		sb.WriteString("-")
+	}
+	if t.Key != KeyText {
+		sb.WriteString(t.Key)
+		sb.WriteString(":")
+		sb.WriteString(t.Op)
+	}
+	for i, v := range t.Values {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		if strings.Contains(v, " ") {
+			v = `"` + v + `"`
+		}
+		sb.WriteString(v)
+	}
+	return sb.String()
+}
+
+// split breaks s on spaces outside of double quotes and removes the quotes
+func split(s string) ([]string, error) {
+	var words []string
+	var sb strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case (r == ' ' || r == '\t') && !quoted:
+			if sb.Len() > 0 {
+				words = append(words, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if sb.Len() > 0 {
+		words = append(words, sb.String())
+	}
+	return words, nil
+}
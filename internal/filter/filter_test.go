@@ -0,0 +1,233 @@
+package filter
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/store"
+)
+
+var testNow = time.Date(2025, 3, 12, 12, 0, 0, 0, time.UTC)
+
+var testProject = &models.Project{
+	ID:     "P1",
+	Owner:  "octo",
+	Number: 1,
+	Title:  "Roadmap",
+	Fields: []models.Field{
+		{ID: "F1", Name: "Status", Type: models.FieldTypeSingleSelect, Options: []models.FieldOption{
+			{ID: "o1", Name: "Todo"}, {ID: "o2", Name: "In Progress"}, {ID: "o3", Name: "Done"},
+		}},
+		{ID: "F2", Name: "Estimate", Type: models.FieldTypeNumber},
+		{ID: "F3", Name: "Sprint", Type: models.FieldTypeIteration, Iterations: []models.Iteration{
+			{ID: "i1", Title: "Sprint 1", StartDate: "2025-02-24", Duration: 14},
+			{ID: "i2", Title: "Sprint 2", StartDate: "2025-03-10", Duration: 14},
+			{ID: "i3", Title: "Sprint 3", StartDate: "2025-03-24", Duration: 14},
+		}},
+		{ID: "F4", Name: "Due", Type: models.FieldTypeDate},
+	},
+}
+
+var testItems = []models.Item{
+	{
+		ID: "a", Type: models.ItemTypeIssue, Title: "Fix login bug", State: "OPEN",
+		Repository: "octo/app", Assignees: []string{"mona"}, Labels: []string{"bug"}, Milestone: "v1",
+		UpdatedAt: testNow.Add(-2 * 24 * time.Hour),
+		Values: map[string]models.FieldValue{
+			"Status":   {Text: "In Progress", OptionID: "o2"},
+			"Estimate": {Text: "3", Number: 3},
+			"Sprint":   {Text: "Sprint 2", IterationID: "i2"},
+			"Due":      {Text: "2025-03-14"},
+		},
+	},
+	{
+		ID: "b", Type: models.ItemTypePullRequest, Title: "Add dark mode", State: "MERGED",
+		Repository: "octo/app", Assignees: []string{"hubot", "Mona"}, Labels: []string{"feature", "ui"},
+		UpdatedAt: testNow.Add(-10 * 24 * time.Hour),
+		Values: map[string]models.FieldValue{
+			"Status":   {Text: "Done", OptionID: "o3"},
+			"Estimate": {Text: "8", Number: 8},
+			"Sprint":   {Text: "Sprint 1", IterationID: "i1"},
+		},
+	},
+	{
+		ID: "c", Type: models.ItemTypeDraftIssue, Title: "Plan the launch",
+		UpdatedAt: testNow.Add(-1 * time.Hour),
+		Values: map[string]models.FieldValue{
+			"Sprint": {Text: "Sprint 3", IterationID: "i3"},
+		},
+	},
+	{
+		ID: "d", Type: models.ItemTypeIssue, Title: "Docs typo", State: "CLOSED",
+		Repository: "octo/docs", Labels: []string{"docs", "Bug"}, Milestone: "v2", Archived: true,
+		UpdatedAt: testNow.Add(-40 * 24 * time.Hour),
+		Values: map[string]models.FieldValue{
+			"Status":   {Text: "Todo", OptionID: "o1"},
+			"Estimate": {Text: "1", Number: 1},
+			"Due":      {Text: "2025-02-01"},
+		},
+	},
+}
+
+var testEnv = Env{Viewer: "mona", Project: testProject, Now: testNow}
+
+// filterTests lists queries with the items they match; each is checked with
+// both Match and SQL so that the two stay in agreement
+var filterTests = []struct {
+	query string
+	want  []string
+}{
+	{"", []string{"a", "b", "c", "d"}},
+	{"bug", []string{"a"}},
+	{"title:DARK", []string{"b"}},
+	{"is:open", []string{"a", "c"}},
+	{"is:closed", []string{"b", "d"}},
+	{"is:merged", []string{"b"}},
+	{"is:draft", []string{"c"}},
+	{"is:issue,pr", []string{"a", "b", "d"}},
+	{"is:archived", []string{"d"}},
+	{"-is:archived", []string{"a", "b", "c"}},
+	{"assignee:@me", []string{"a", "b"}},
+	{"assignee:hubot", []string{"b"}},
+	{"-assignee:@me", []string{"c", "d"}},
+	{"no:assignee", []string{"c", "d"}},
+	{"has:assignee", []string{"a", "b"}},
+	{"label:bug", []string{"a", "d"}},
+	{"label:ui,docs", []string{"b", "d"}},
+	{"-label:bug", []string{"b", "c"}},
+	{"no:label", []string{"c"}},
+	{"milestone:V1", []string{"a"}},
+	{"-milestone:v1", []string{"b", "c", "d"}},
+	{"no:milestone", []string{"b", "c"}},
+	{"repo:octo/app", []string{"a", "b"}},
+	{"status:todo", []string{"d"}},
+	{`status:"In Progress"`, []string{"a"}},
+	{"status:todo,done", []string{"b", "d"}},
+	{"-status:done", []string{"a", "c", "d"}},
+	{"no:status", []string{"c"}},
+	{"has:status", []string{"a", "b", "d"}},
+	{"estimate:3", []string{"a"}},
+	{"estimate:>2", []string{"a", "b"}},
+	{"estimate:<=3", []string{"a", "d"}},
+	{"-estimate:>2", []string{"c", "d"}},
+	{"sprint:@current", []string{"a"}},
+	{"sprint:@next", []string{"c"}},
+	{"sprint:@previous", []string{"b"}},
+	{`sprint:"sprint 1"`, []string{"b"}},
+	{"-sprint:@current", []string{"b", "c", "d"}},
+	{"due:2025-03-14", []string{"a"}},
+	{"due:<@today", []string{"d"}},
+	{"due:>=2025-03-01", []string{"a"}},
+	{"updated:>7d", []string{"a", "c"}},
+	{"updated:<30d", []string{"d"}},
+	{"updated:@today", []string{"c"}},
+	{"updated:2025-03-10", []string{"a"}},
+	{"is:open assignee:@me", []string{"a"}},
+	{"-is:draft -label:bug", []string{"b"}},
+	{"unknown:x", nil},
+	{"-unknown:x", []string{"a", "b", "c", "d"}},
+}
+
+func TestMatch(t *testing.T) {
+	for _, tt := range filterTests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.query, err)
+			}
+			var got []string
+			for _, item := range testItems {
+				if q.Match(item, testEnv) {
+					got = append(got, item.ID)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Match(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQL(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	s, err := store.Open()
+	if err != nil {
+		t.Fatalf("opening the cache: %v", err)
+	}
+	defer s.Close()
+	if err := s.SaveProject(testProject, testItems); err != nil {
+		t.Fatalf("saving the project: %v", err)
+	}
+
+	for _, tt := range filterTests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.query, err)
+			}
+			where, args, err := q.SQL(testEnv)
+			if err != nil {
+				t.Fatalf("SQL(%q): %v", tt.query, err)
+			}
+			items, err := s.Items(testProject.ID, where, args...)
+			if err != nil {
+				t.Fatalf("querying %q: %v", where, err)
+			}
+			var got []string
+			for _, item := range items {
+				got = append(got, item.ID)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SQL(%q) = %v, want %v\n%s %v", tt.query, got, tt.want, where, args)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, query := range []string{
+		`status:"In Progress`,
+		":value",
+		"status:",
+		"estimate:>1,2",
+		"is:unknown",
+		"updated:>soon",
+	} {
+		if _, err := Parse(query); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", query)
+		}
+	}
+}
+
+func TestEnvIteration(t *testing.T) {
+	field := testProject.Field("Sprint")
+	tests := []struct {
+		now  time.Time
+		v    string
+		want string
+	}{
+		{testNow, "@current", "Sprint 2"},
+		{testNow, "@NEXT", "Sprint 3"},
+		{testNow, "@previous", "Sprint 1"},
+		{testNow, "Sprint 1", "Sprint 1"},
+		// The first day of an iteration belongs to it
+		{time.Date(2025, 3, 24, 0, 0, 0, 0, time.UTC), "@current", "Sprint 3"},
+		{time.Date(2025, 3, 24, 0, 0, 0, 0, time.UTC), "@previous", "Sprint 2"},
+		// Past the last iteration nothing is current or next
+		{time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), "@current", "\x00"},
+		{time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), "@next", "\x00"},
+		// Before the first iteration the upcoming one is next
+		{time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), "@current", "\x00"},
+		{time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), "@next", "Sprint 1"},
+	}
+	for _, tt := range tests {
+		env := Env{Project: testProject, Now: tt.now}
+		if got := env.Iteration(field, tt.v); got != tt.want {
+			t.Errorf("Iteration(%s, %q) = %q, want %q", tt.now.Format(time.DateOnly), tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// Match reports whether item satisfies every term of the query
+func (q Query) Match(item models.Item, env Env) bool {
+	for _, t := range q.Terms {
+		if t.match(item, env) == t.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Term) match(item models.Item, env Env) bool {
+	for _, v := range t.Values {
+		if t.matchValue(item, env, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Term) matchValue(item models.Item, env Env, v string) bool {
+	switch t.Key {
+	case KeyText, KeyTitle:
+		return strings.Contains(strings.ToLower(item.Title), strings.ToLower(v))
+	case KeyIs:
+		return matchIs(item, strings.ToLower(v))
+	case KeyNo:
+		return isEmpty(item, env, v)
+	case KeyHas:
+		return !isEmpty(item, env, v)
+	case KeyAssignee:
+		return containsFold(item.Assignees, env.User(v))
+	case KeyLabel:
+		return containsFold(item.Labels, v)
+	case KeyMilestone:
+		return strings.EqualFold(item.Milestone, v)
+	case KeyRepo:
+		return strings.EqualFold(item.Repository, v)
+	case KeyUpdated:
+		at, err := env.Time(v)
+		if err != nil {
+			return false
+		}
+		if t.Op == "" {
+			return item.UpdatedAt.In(at.Location()).Format(time.DateOnly) == at.Format(time.DateOnly)
+		}
+		return compareTime(item.UpdatedAt, at, t.Op)
+	}
+	return t.matchField(item, env, v)
+}
+
+// matchField compares the value of the project field named by the term key
+func (t Term) matchField(item models.Item, env Env, v string) bool {
+	field := env.Field(t.Key)
+	have := ""
+	if field != nil {
+		have = item.Value(field.Name)
+	} else {
+		for name, value := range item.Values {
+			if strings.EqualFold(name, t.Key) {
+				have = value.Text
+			}
+		}
+	}
+
+	if field != nil {
+		switch field.Type {
+		case models.FieldTypeIteration:
+			v = env.Iteration(field, v)
+		case models.FieldTypeDate:
+			if at, err := env.Time(v); err == nil {
+				v = at.Format(time.DateOnly)
+			}
+		}
+	}
+
+	if t.Op == "" {
+		return strings.EqualFold(have, v)
+	}
+	if have == "" {
+		return false
+	}
+	if a, err := strconv.ParseFloat(have, 64); err == nil {
+		if b, err := strconv.ParseFloat(v, 64); err == nil {
+			return compare(cmpFloat(a, b), t.Op)
+		}
+	}
+	// Dates in 2006-01-02 form order correctly as strings
+	return compare(strings.Compare(strings.ToLower(have), strings.ToLower(v)), t.Op)
+}
+
+func matchIs(item models.Item, v string) bool {
+	switch v {
+	case "open":
+		return item.Type == models.ItemTypeDraftIssue || strings.EqualFold(item.State, "OPEN")
+	case "closed":
+		return strings.EqualFold(item.State, "CLOSED") || strings.EqualFold(item.State, "MERGED")
+	case "merged":
+		return strings.EqualFold(item.State, "MERGED")
+	case "draft":
+		return item.Type == models.ItemTypeDraftIssue
+	case "issue":
+		return item.Type == models.ItemTypeIssue
+	case "pr":
+		return item.Type == models.ItemTypePullRequest
+	case "archived":
+		return item.Archived
+	}
+	return false
+}
+
+// isEmpty reports whether the named attribute or field of item has no value
+func isEmpty(item models.Item, env Env, name string) bool {
+	switch strings.ToLower(name) {
+	case KeyAssignee:
+		return len(item.Assignees) == 0
+	case KeyLabel:
+		return len(item.Labels) == 0
+	case KeyMilestone:
+		return item.Milestone == ""
+	}
+	if field := env.Field(name); field != nil {
+		return item.Value(field.Name) == ""
+	}
+	for n, value := range item.Values {
+		if strings.EqualFold(n, name) {
+			return value.Text == ""
+		}
+	}
+	return true
+}
+
+func containsFold(list []string, v string) bool {
+	for _, s := range list {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareTime(have, want time.Time, op string) bool {
+	switch {
+	case have.Before(want):
+		return compare(-1, op)
+	case have.After(want):
+		return compare(1, op)
+	}
+	return compare(0, op)
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compare applies op to the result of a three-way comparison
+func compare(c int, op string) bool {
+	switch op {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return c == 0
+}
@@ -0,0 +1,143 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/store"
+)
+
+// SQL compiles the query into a boolean DuckDB expression over the items table
+// of the local cache, returning the expression and its positional arguments.
+// The table must provide the columns title, type, state, archived, milestone,
+// repository, updated_at, assignees and labels (JSON string arrays) and
+// field_values (a JSON object of models.FieldValue keyed by field name).
+func (q Query) SQL(env Env) (string, []any, error) {
+	if q.Empty() {
+		return "TRUE", nil, nil
+	}
+	var clauses []string
+	var args []any
+	for _, t := range q.Terms {
+		var alternatives []string
+		for _, v := range t.Values {
+			clause, clauseArgs, err := t.sqlValue(env, v)
+			if err != nil {
+				return "", nil, err
+			}
+			alternatives = append(alternatives, clause)
+			args = append(args, clauseArgs...)
+		}
+		// Missing values compare as NULL; they must not match, and must match
+		// once negated, as they do in Match
+		clause := "coalesce(" + strings.Join(alternatives, " OR ") + ", FALSE)"
+		if t.Negate {
+			clause = "NOT " + clause
+		}
+		clauses = append(clauses, clause)
+	}
+	return strings.Join(clauses, " AND "), args, nil
+}
+
+func (t Term) sqlValue(env Env, v string) (string, []any, error) {
+	switch t.Key {
+	case KeyText, KeyTitle:
+		return "contains(lower(title), ?)", []any{strings.ToLower(v)}, nil
+	case KeyIs:
+		return sqlIs(strings.ToLower(v)), nil, nil
+	case KeyNo:
+		clause, args := sqlEmpty(env, v)
+		return clause, args, nil
+	case KeyHas:
+		clause, args := sqlEmpty(env, v)
+		return "NOT " + clause, args, nil
+	case KeyAssignee:
+		return sqlListContains("assignees"), []any{strings.ToLower(env.User(v))}, nil
+	case KeyLabel:
+		return sqlListContains("labels"), []any{strings.ToLower(v)}, nil
+	case KeyMilestone:
+		return "lower(milestone) = ?", []any{strings.ToLower(v)}, nil
+	case KeyRepo:
+		return "lower(repository) = ?", []any{strings.ToLower(v)}, nil
+	case KeyUpdated:
+		at, err := env.Time(v)
+		if err != nil {
+			return "", nil, err
+		}
+		if t.Op == "" {
+			day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+			return "(updated_at >= ? AND updated_at < ?)", []any{day, day.AddDate(0, 0, 1)}, nil
+		}
+		return "updated_at " + t.Op + " ?", []any{at}, nil
+	}
+	return t.sqlField(env, v)
+}
+
+func (t Term) sqlField(env Env, v string) (string, []any, error) {
+	name := t.Key
+	field := env.Field(t.Key)
+	if field != nil {
+		name = field.Name
+		switch field.Type {
+		case models.FieldTypeIteration:
+			v = env.Iteration(field, v)
+		case models.FieldTypeDate:
+			if at, err := env.Time(v); err == nil {
+				v = at.Format(time.DateOnly)
+			}
+		}
+	}
+	value := "json_extract_string(field_values, ?)"
+	path := store.FieldPath(name)
+
+	if t.Op == "" {
+		return "lower(" + value + ") = ?", []any{path, strings.ToLower(v)}, nil
+	}
+	if n, err := strconv.ParseFloat(v, 64); err == nil && (field == nil || field.Type == models.FieldTypeNumber) {
+		return "TRY_CAST(" + value + " AS DOUBLE) " + t.Op + " ?", []any{path, n}, nil
+	}
+	return "lower(" + value + ") " + t.Op + " ?", []any{path, strings.ToLower(v)}, nil
+}
+
+func sqlIs(v string) string {
+	switch v {
+	case "open":
+		return fmt.Sprintf("(type = '%s' OR upper(state) = 'OPEN')", models.ItemTypeDraftIssue)
+	case "closed":
+		return "upper(state) IN ('CLOSED', 'MERGED')"
+	case "merged":
+		return "upper(state) = 'MERGED'"
+	case "draft":
+		return fmt.Sprintf("type = '%s'", models.ItemTypeDraftIssue)
+	case "issue":
+		return fmt.Sprintf("type = '%s'", models.ItemTypeIssue)
+	case "pr":
+		return fmt.Sprintf("type = '%s'", models.ItemTypePullRequest)
+	case "archived":
+		return "archived"
+	}
+	return "FALSE"
+}
+
+func sqlEmpty(env Env, name string) (string, []any) {
+	switch strings.ToLower(name) {
+	case KeyAssignee:
+		return "coalesce(json_array_length(assignees), 0) = 0", nil
+	case KeyLabel:
+		return "coalesce(json_array_length(labels), 0) = 0", nil
+	case KeyMilestone:
+		return "coalesce(milestone, '') = ''", nil
+	}
+	if field := env.Field(name); field != nil {
+		name = field.Name
+	}
+	return "coalesce(json_extract_string(field_values, ?), '') = ''", []any{store.FieldPath(name)}
+}
+
+// sqlListContains matches a lower-cased argument against a JSON array column
+func sqlListContains(column string) string {
+	return "list_contains(list_transform(CAST(" + column + " AS VARCHAR[]), x -> lower(x)), ?)"
+}
@@ -1,10 +1,13 @@
 package ghc
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"os/exec"
 	"strings"
-
-	"github.com/cli/go-gh"
+	"time"
 )
 
 func newCommand(s string) GHCommand {
@@ -20,16 +23,92 @@ func newCommandArgs(args ...string) GHCommand {
 type GHCommand []string
 
 func (c GHCommand) Exec() (string, error) {
-	out, _, err := gh.Exec(c.StringArray()...)
+	return c.ExecContext(context.Background())
+}
+
+// ExecContext runs the command, killing gh when ctx is cancelled. Commands
+// rejected by a rate limit are retried after a backoff, or once the budget
+// resets.
+func (c GHCommand) ExecContext(ctx context.Context) (string, error) {
+	path, err := exec.LookPath("gh")
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("could not find gh executable in PATH. error: %w", err)
+	}
+	for attempt := 0; ; attempt++ {
+		if d := throttleDelay(time.Now()); d > 0 {
+			if err := sleep(ctx, d); err != nil {
+				return "", err
+			}
+		}
+		var stdOut, stdErr bytes.Buffer
+		cmd := exec.CommandContext(ctx, path, c.StringArray()...)
+		cmd.Stdout = &stdOut
+		cmd.Stderr = &stdErr
+		start := time.Now()
+		err := cmd.Run()
+		c.log(time.Since(start), err, stdErr.String())
+		if err == nil {
+			return stdOut.String(), nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		err = classify(fmt.Errorf("failed to run gh: %s. error: %w", stdErr.String(), err), stdErr.String())
+
+		limited, secondary := rateLimitOutput(stdErr.String())
+		if !limited || attempt >= maxRetries {
+			return "", err
+		}
+		wait := backoff(attempt)
+		if secondary {
+			wait = max(wait, time.Minute)
+		} else if b, ok := RateLimit(); ok && b.Reset.After(time.Now()) {
+			wait = time.Until(b.Reset) + time.Second
+		}
+		if wait > maxWait {
+			return "", err
+		}
+		logger.Printf("gh %s was rate limited, retrying in %s (%d of %d)", c.name(), wait.Round(time.Millisecond), attempt+1, maxRetries)
+		if err := sleep(ctx, wait); err != nil {
+			return "", err
+		}
 	}
-	return out.String(), nil
+}
+
+// log records a finished run of the command, with its arguments and error
+// output in debug mode
+func (c GHCommand) log(took time.Duration, err error, stderr string) {
+	if !debug {
+		logger.Printf("gh %s in %s", c.name(), took.Round(time.Millisecond))
+		return
+	}
+	line := fmt.Sprintf("gh %s: in %s", describeArgs(c), took.Round(time.Millisecond))
+	if err != nil {
+		line += fmt.Sprintf(", %v: %s", err, redact(strings.TrimSpace(stderr)))
+	}
+	logger.Print(line)
+}
+
+// name returns the subcommand for logs, e.g. "project item-edit"
+func (c GHCommand) name() string {
+	var words []string
+	for _, arg := range c {
+		if strings.HasPrefix(arg, "-") || len(words) == 2 {
+			break
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
 }
 
 // ExecUnmarshal unmarshals the output of the command into the provided interface with JSON
 func (c GHCommand) ExecUnmarshal(i any) error {
-	out, err := c.Exec()
+	return c.ExecUnmarshalContext(context.Background(), i)
+}
+
+// ExecUnmarshalContext is ExecUnmarshal stopping when ctx is cancelled
+func (c GHCommand) ExecUnmarshalContext(ctx context.Context, i any) error {
+	out, err := c.ExecContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,88 @@
+package ghc
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/prnk28/gh-pm/internal/clierr"
+)
+
+// ErrNotFound is returned when a project or repository does not exist or cannot be seen by the viewer
+var ErrNotFound error = clierr.New(clierr.KindNotFound, errors.New("not found"), "")
+
+// classify attaches the kind of a failed API request or gh command to err,
+// together with a hint on how to fix it. stderr is the error output of gh, if
+// any.
+func classify(err error, stderr string) error {
+	if err == nil {
+		return nil
+	}
+	if e := clierr.Classify(err); e.Kind != clierr.KindUnknown {
+		return err
+	}
+	kind, hint := clierr.KindUnknown, ""
+
+	var httpErr api.HTTPError
+	var gqlErr api.GQLError
+	switch {
+	case errors.As(err, &httpErr):
+		switch httpErr.StatusCode {
+		case http.StatusUnauthorized:
+			kind, hint = clierr.KindAuth, clierr.LoginHint
+		case http.StatusNotFound:
+			kind = clierr.KindNotFound
+		case http.StatusTooManyRequests:
+			kind, hint = clierr.KindRateLimit, clierr.RateLimitHint
+		case http.StatusForbidden:
+			kind, hint = classifyMessage(httpErr.Message)
+			if kind == clierr.KindUnknown && httpErr.Headers.Get("X-RateLimit-Remaining") == "0" {
+				kind, hint = clierr.KindRateLimit, clierr.RateLimitHint
+			}
+		}
+	case errors.As(err, &gqlErr):
+		for _, e := range gqlErr.Errors {
+			switch e.Type {
+			case "NOT_FOUND":
+				kind = clierr.KindNotFound
+			case "INSUFFICIENT_SCOPES":
+				kind, hint = clierr.KindAuth, clierr.ScopeHint
+			case "FORBIDDEN":
+				kind = clierr.KindAuth
+			case "RATE_LIMITED":
+				kind, hint = clierr.KindRateLimit, clierr.RateLimitHint
+			}
+			if kind != clierr.KindUnknown {
+				break
+			}
+		}
+	default:
+		kind, hint = classifyMessage(stderr)
+	}
+	if kind == clierr.KindUnknown {
+		return err
+	}
+	return clierr.New(kind, err, hint)
+}
+
+// classifyMessage recognizes the errors gh and the API report in plain text
+func classifyMessage(msg string) (clierr.Kind, string) {
+	s := strings.ToLower(msg)
+	switch {
+	case strings.Contains(s, "missing required scopes"), strings.Contains(s, "insufficient_scopes"),
+		strings.Contains(s, "has not been granted the required scopes"):
+		return clierr.KindAuth, clierr.ScopeHint
+	case strings.Contains(s, "gh auth login"), strings.Contains(s, "bad credentials"),
+		strings.Contains(s, "authentication required"), strings.Contains(s, "authentication token not found"):
+		return clierr.KindAuth, clierr.LoginHint
+	case strings.Contains(s, "rate limit"), strings.Contains(s, "submitted too quickly"):
+		return clierr.KindRateLimit, clierr.RateLimitHint
+	case strings.Contains(s, "could not resolve to"), strings.Contains(s, "http 404"), strings.Contains(s, "not found"):
+		return clierr.KindNotFound, ""
+	case strings.Contains(s, "no such host"), strings.Contains(s, "connection refused"),
+		strings.Contains(s, "i/o timeout"), strings.Contains(s, "error connecting to"):
+		return clierr.KindNetwork, clierr.NetworkHint
+	}
+	return clierr.KindUnknown, ""
+}
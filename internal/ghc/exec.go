@@ -1,6 +1,13 @@
 package ghc
 
-import "github.com/prnk28/gh-pm/internal/models"
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
 
 func GetProjects() ([]models.ProjectsJson, error) {
 	var projects models.ProjectsListJson
@@ -11,6 +18,36 @@ func GetProjects() ([]models.ProjectsJson, error) {
 	return projects, nil
 }
 
+// GetOwnerProjects lists the projects of a user or organization, or of the viewer when owner is empty
+func GetOwnerProjects(owner string) ([]models.ProjectsJson, error) {
+	return GetOwnerProjectsContext(context.Background(), owner)
+}
+
+// maxOwnerProjects caps the projects listed for an owner; gh fetches them a
+// page at a time up to this limit
+const maxOwnerProjects = 1000
+
+// GetOwnerProjectsContext is GetOwnerProjects stopping when ctx is cancelled.
+// An owner with more than maxOwnerProjects projects is listed in part, with a
+// warning.
+func GetOwnerProjectsContext(ctx context.Context, owner string) ([]models.ProjectsJson, error) {
+	if owner == "" {
+		owner = "@me"
+	}
+	var list struct {
+		Projects   models.ProjectsListJson `json:"projects"`
+		TotalCount int                     `json:"totalCount"`
+	}
+	err := newCommandArgs("project", "list", "--owner", owner, "--limit", strconv.Itoa(maxOwnerProjects), "--format", "json").ExecUnmarshalContext(ctx, &list)
+	if err != nil {
+		return nil, err
+	}
+	if list.TotalCount > len(list.Projects) {
+		fmt.Fprintf(os.Stderr, "Warning: only the first %d of the %d projects of %s are listed\n", len(list.Projects), list.TotalCount, owner)
+	}
+	return list.Projects, nil
+}
+
 func GetProjectItems(owner string) ([]models.CardsJson, error) {
 	var projectCards models.CardsListJson
 	err := QueryProjectItemList.ExecUnmarshal(&projectCards)
@@ -28,3 +65,58 @@ func GetWhoami() (*models.UserJson, error) {
 	}
 	return &userInfo, nil
 }
+
+// CreateProject creates a project owned by owner, or by the viewer when owner is empty
+func CreateProject(owner, title, description string) (*models.ProjectsJson, error) {
+	if owner == "" {
+		owner = "@me"
+	}
+	var project models.ProjectsJson
+	err := newCommandArgs("project", "create", "--owner", owner, "--title", title, "--format", "json").ExecUnmarshal(&project)
+	if err != nil {
+		return nil, err
+	}
+	return setDescription(&project, owner, description)
+}
+
+// CopyProject copies the fields, views and workflows of a project into a new
+// project owned by owner, or by the viewer when owner is empty. With drafts the
+// draft issues are copied too.
+func CopyProject(sourceOwner string, number int, owner, title, description string, drafts bool) (*models.ProjectsJson, error) {
+	if sourceOwner == "" {
+		sourceOwner = "@me"
+	}
+	if owner == "" {
+		owner = "@me"
+	}
+	args := []string{"project", "copy", strconv.Itoa(number), "--source-owner", sourceOwner, "--target-owner", owner, "--title", title, "--format", "json"}
+	if drafts {
+		args = append(args, "--drafts")
+	}
+	var project models.ProjectsJson
+	if err := newCommandArgs(args...).ExecUnmarshal(&project); err != nil {
+		return nil, err
+	}
+	return setDescription(&project, owner, description)
+}
+
+func setDescription(project *models.ProjectsJson, owner, description string) (*models.ProjectsJson, error) {
+	if description == "" {
+		return project, nil
+	}
+	_, err := newCommandArgs("project", "edit", strconv.Itoa(int(project.Number)), "--owner", owner, "--description", description).Exec()
+	if err != nil {
+		return nil, err
+	}
+	project.ShortDescription = description
+	return project, nil
+}
+
+// DeleteProject deletes a project owned by owner, or by the viewer when owner is empty
+func DeleteProject(owner string, number int) error {
+	if owner == "" {
+		owner = "@me"
+	}
+	_, err := newCommandArgs("project", "delete", strconv.Itoa(number), "--owner", owner).Exec()
+	return err
+}
@@ -0,0 +1,113 @@
+package ghc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// newIterations is the number of iterations created with a new iteration field
+const newIterations = 3
+
+// CreateField adds a text, number, date, single select or iteration field to a
+// project. Single select fields get the options of field; iteration fields get
+// a few iterations of field.IterationDuration days starting on the last
+// field.IterationStartDay.
+func CreateField(projectID string, field models.Field) (*models.Field, error) {
+	input := map[string]interface{}{
+		"projectId": projectID,
+		"name":      field.Name,
+		"dataType":  string(field.Type),
+	}
+	switch field.Type {
+	case models.FieldTypeText, models.FieldTypeNumber, models.FieldTypeDate:
+	case models.FieldTypeSingleSelect:
+		if len(field.Options) == 0 {
+			return nil, fmt.Errorf("single select field %q needs at least one option", field.Name)
+		}
+		input["singleSelectOptions"] = optionsInput(field.Options, false)
+	case models.FieldTypeIteration:
+		input["iterationConfiguration"] = iterationInput(field, time.Now())
+	default:
+		return nil, fmt.Errorf("fields of type %s cannot be created", field.Type)
+	}
+
+	var resp struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field gqlFieldNode `json:"projectV2Field"`
+		} `json:"createProjectV2Field"`
+	}
+	if err := graphQL(gqlCreateField, map[string]interface{}{"input": input}, &resp); err != nil {
+		return nil, err
+	}
+	created := resp.CreateProjectV2Field.ProjectV2Field.toModel()
+	return &created, nil
+}
+
+// UpdateField renames a field and, for single select fields, replaces its
+// options. Options keeping their ID keep their values on items; options
+// without one are created.
+func UpdateField(field models.Field) (*models.Field, error) {
+	input := map[string]interface{}{
+		"fieldId": field.ID,
+		"name":    field.Name,
+	}
+	if field.Type == models.FieldTypeSingleSelect {
+		input["singleSelectOptions"] = optionsInput(field.Options, true)
+	}
+	var resp struct {
+		UpdateProjectV2Field struct {
+			ProjectV2Field gqlFieldNode `json:"projectV2Field"`
+		} `json:"updateProjectV2Field"`
+	}
+	if err := graphQL(gqlUpdateField, map[string]interface{}{"input": input}, &resp); err != nil {
+		return nil, err
+	}
+	updated := resp.UpdateProjectV2Field.ProjectV2Field.toModel()
+	return &updated, nil
+}
+
+// DeleteField removes a field and all of its values from a project
+func DeleteField(fieldID string) error {
+	var resp struct{}
+	return graphQL(gqlDeleteField, map[string]interface{}{"input": map[string]interface{}{"fieldId": fieldID}}, &resp)
+}
+
+func optionsInput(options []models.FieldOption, withIDs bool) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(options))
+	for _, o := range options {
+		color := o.Color
+		if color == "" {
+			color = "GRAY"
+		}
+		opt := map[string]interface{}{"name": o.Name, "color": color, "description": o.Description}
+		if withIDs && o.ID != "" {
+			opt["id"] = o.ID
+		}
+		out = append(out, opt)
+	}
+	return out
+}
+
+func iterationInput(field models.Field, now time.Time) map[string]interface{} {
+	duration := field.IterationDuration
+	if duration <= 0 {
+		duration = 14
+	}
+	// Start on the last start day so that the first iteration is the current one
+	start := now.AddDate(0, 0, -int((7+now.Weekday()-field.IterationStartDay)%7))
+	iterations := make([]map[string]interface{}, 0, newIterations)
+	for i := 0; i < newIterations; i++ {
+		iterations = append(iterations, map[string]interface{}{
+			"title":     fmt.Sprintf("Iteration %d", i+1),
+			"startDate": start.AddDate(0, 0, i*duration).Format(time.DateOnly),
+			"duration":  duration,
+		})
+	}
+	return map[string]interface{}{
+		"startDate":  start.Format(time.DateOnly),
+		"duration":   duration,
+		"iterations": iterations,
+	}
+}
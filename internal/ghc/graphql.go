@@ -0,0 +1,48 @@
+package ghc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/api"
+)
+
+var (
+	gqlOnce   sync.Once
+	gqlClient api.GQLClient
+	gqlErr    error
+)
+
+// graphQL runs a raw GraphQL document against the API and decodes the data into out
+func graphQL(query string, variables map[string]interface{}, out interface{}) error {
+	return graphQLContext(context.Background(), query, variables, out)
+}
+
+// graphQLContext is graphQL stopping when ctx is cancelled
+func graphQLContext(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	gqlOnce.Do(func() {
+		gqlClient, gqlErr = gh.GQLClient(&api.ClientOptions{
+			Transport: rateLimitTransport{base: http.DefaultTransport},
+		})
+	})
+	if gqlErr != nil {
+		return classify(gqlErr, gqlErr.Error())
+	}
+	return classify(gqlClient.DoWithContext(ctx, query, variables, out), "")
+}
+
+// Ping sends the cheapest GraphQL query and returns the login of the viewer,
+// recording the rate limit budget on the way
+func Ping(ctx context.Context) (string, error) {
+	var data struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	if err := graphQLContext(ctx, gqlPing, nil, &data); err != nil {
+		return "", err
+	}
+	return data.Viewer.Login, nil
+}
@@ -0,0 +1,203 @@
+package ghc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// AddDraft adds a draft issue to a project and returns the new item
+func AddDraft(projectID, title, body string) (models.Item, error) {
+	input := map[string]interface{}{"projectId": projectID, "title": title}
+	if body != "" {
+		input["body"] = body
+	}
+	var resp struct {
+		AddProjectV2DraftIssue struct {
+			ProjectItem gqlItemNode `json:"projectItem"`
+		} `json:"addProjectV2DraftIssue"`
+	}
+	if err := graphQL(gqlAddDraft, map[string]interface{}{"input": input}, &resp); err != nil {
+		return models.Item{}, err
+	}
+	return resp.AddProjectV2DraftIssue.ProjectItem.toModel(), nil
+}
+
+// UpdateDraft changes the title and body of the draft issue behind an item,
+// given its content ID; nil values are left as they are
+func UpdateDraft(draftID string, title, body *string) error {
+	input := map[string]interface{}{"draftIssueId": draftID}
+	if title != nil {
+		input["title"] = *title
+	}
+	if body != nil {
+		input["body"] = *body
+	}
+	var resp struct{}
+	return graphQL(gqlUpdateDraft, map[string]interface{}{"input": input}, &resp)
+}
+
+// ConvertDraft turns a draft item into an issue of repo, given as owner/name.
+// The item keeps its ID; the converted item is returned.
+func ConvertDraft(itemID, repo string) (models.Item, error) {
+	repoID, err := GetRepositoryID(repo)
+	if err != nil {
+		return models.Item{}, err
+	}
+	var resp struct {
+		ConvertProjectV2DraftIssueItemToIssue struct {
+			Item gqlItemNode `json:"item"`
+		} `json:"convertProjectV2DraftIssueItemToIssue"`
+	}
+	input := map[string]interface{}{"itemId": itemID, "repositoryId": repoID}
+	if err := graphQL(gqlConvertDraft, map[string]interface{}{"input": input}, &resp); err != nil {
+		return models.Item{}, err
+	}
+	return resp.ConvertProjectV2DraftIssueItemToIssue.Item.toModel(), nil
+}
+
+// DeleteItem removes an item from a project; a draft issue is deleted with it
+func DeleteItem(projectID, itemID string) error {
+	var resp struct{}
+	return graphQL(gqlDeleteItem, map[string]interface{}{
+		"input": map[string]interface{}{"projectId": projectID, "itemId": itemID},
+	}, &resp)
+}
+
+// GetRepositoryID returns the node ID of a repository given as owner/name
+func GetRepositoryID(repo string) (string, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" {
+		return "", fmt.Errorf("invalid repository %q, expected owner/name", repo)
+	}
+	var resp struct {
+		Repository *struct {
+			ID string `json:"id"`
+		} `json:"repository"`
+	}
+	if err := graphQL(gqlRepositoryID, map[string]interface{}{"owner": owner, "name": name}, &resp); err != nil {
+		return "", err
+	}
+	if resp.Repository == nil {
+		return "", fmt.Errorf("repository %s %w", repo, ErrNotFound)
+	}
+	return resp.Repository.ID, nil
+}
+
+// GetOwnerRepos lists the names of the unarchived repositories of a user or
+// organization, or of the viewer when owner is empty
+func GetOwnerRepos(owner string) ([]string, error) {
+	args := []string{"repo", "list"}
+	if owner != "" && owner != "@me" {
+		args = append(args, owner)
+	}
+	args = append(args, "--no-archived", "--limit", "200", "--json", "nameWithOwner")
+	var repos []struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	}
+	if err := newCommandArgs(args...).ExecUnmarshal(&repos); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.NameWithOwner)
+	}
+	return names, nil
+}
+
+// gqlContentNode is an issue or pull request selected by gqlContentFields
+type gqlContentNode struct {
+	Typename   string `json:"__typename"`
+	ID         string `json:"id"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	State      string `json:"state"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+}
+
+// toModel returns the content as an item that is not on a project yet, so
+// only ContentID identifies it
+func (n gqlContentNode) toModel() models.Item {
+	itemType := models.ItemTypeIssue
+	if n.Typename == "PullRequest" {
+		itemType = models.ItemTypePullRequest
+	}
+	return models.Item{
+		Type:       itemType,
+		ContentID:  n.ID,
+		Number:     n.Number,
+		Title:      n.Title,
+		URL:        n.URL,
+		State:      n.State,
+		Repository: n.Repository.NameWithOwner,
+	}
+}
+
+// GetContent returns the issue or pull request at url as an item that is not
+// on a project yet
+func GetContent(url string) (models.Item, error) {
+	var resp struct {
+		Resource *gqlContentNode `json:"resource"`
+	}
+	if err := graphQL(gqlResource, map[string]interface{}{"url": url}, &resp); err != nil {
+		return models.Item{}, err
+	}
+	if resp.Resource == nil || resp.Resource.ID == "" {
+		return models.Item{}, fmt.Errorf("%s is not an issue or pull request: %w", url, ErrNotFound)
+	}
+	return resp.Resource.toModel(), nil
+}
+
+// SearchContent returns up to limit issues and pull requests matching a
+// GitHub search query such as "repo:org/x is:open label:bug"
+func SearchContent(query string, limit int) ([]models.Item, error) {
+	return SearchContentContext(context.Background(), query, limit)
+}
+
+// SearchContentContext is SearchContent stopping when ctx is cancelled
+func SearchContentContext(ctx context.Context, query string, limit int) ([]models.Item, error) {
+	var items []models.Item
+	vars := map[string]interface{}{"query": query, "first": min(limit, 100), "cursor": nil}
+	for len(items) < limit {
+		var resp struct {
+			Search struct {
+				PageInfo gqlPageInfo      `json:"pageInfo"`
+				Nodes    []gqlContentNode `json:"nodes"`
+			} `json:"search"`
+		}
+		if err := graphQLContext(ctx, gqlSearchContent, vars, &resp); err != nil {
+			return nil, err
+		}
+		for _, n := range resp.Search.Nodes {
+			if n.ID != "" && len(items) < limit {
+				items = append(items, n.toModel())
+			}
+		}
+		if !resp.Search.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = resp.Search.PageInfo.EndCursor
+	}
+	return items, nil
+}
+
+// AddItem adds an issue or pull request, given its content ID, to a project
+// and returns the new item. Adding content already on the project returns
+// the existing item.
+func AddItem(projectID, contentID string) (models.Item, error) {
+	var resp struct {
+		AddProjectV2ItemByID struct {
+			Item gqlItemNode `json:"item"`
+		} `json:"addProjectV2ItemById"`
+	}
+	input := map[string]interface{}{"projectId": projectID, "contentId": contentID}
+	if err := graphQL(gqlAddItem, map[string]interface{}{"input": input}, &resp); err != nil {
+		return models.Item{}, err
+	}
+	return resp.AddProjectV2ItemByID.Item.toModel(), nil
+}
@@ -0,0 +1,113 @@
+package ghc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// maxLoggedValue bounds the length of a string variable or argument in debug
+// logs, so that issue bodies do not flood them
+const maxLoggedValue = 120
+
+var (
+	logger = log.New(io.Discard, "", 0)
+	debug  bool
+)
+
+// SetLogger sends a line per API request and gh command with its status,
+// latency, cost and remaining budget to l, together with the throttling and
+// retries. With debug set, the lines also hold the arguments of gh commands
+// and the variables of GraphQL queries, with secrets redacted.
+func SetLogger(l *log.Logger, withDebug bool) {
+	logger = l
+	debug = withDebug
+}
+
+// secretName matches the names of variables and flags whose values are never logged
+var secretName = regexp.MustCompile(`(?i)token|secret|password|credential|authorization`)
+
+// secretValue matches GitHub tokens wherever they show up
+var secretValue = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+
+// redact hides tokens in s and truncates it to maxLoggedValue
+func redact(s string) string {
+	s = secretValue.ReplaceAllString(s, "[redacted]")
+	if len(s) > maxLoggedValue {
+		s = s[:maxLoggedValue] + "…"
+	}
+	return s
+}
+
+// redactVariables returns GraphQL variables for logging, with the values of
+// secret names hidden and long strings truncated
+func redactVariables(vars map[string]any) map[string]any {
+	out := make(map[string]any, len(vars))
+	for k, v := range vars {
+		if secretName.MatchString(k) {
+			out[k] = "[redacted]"
+			continue
+		}
+		switch v := v.(type) {
+		case string:
+			out[k] = redact(v)
+		case map[string]any:
+			out[k] = redactVariables(v)
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// describeArgs renders the arguments of a gh command for logging, hiding the
+// values of secret flags
+func describeArgs(args []string) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case i > 0 && strings.HasPrefix(args[i-1], "-") && secretName.MatchString(args[i-1]):
+			arg = "[redacted]"
+		case strings.HasPrefix(arg, "-") && strings.Contains(arg, "=") && secretName.MatchString(arg[:strings.Index(arg, "=")]):
+			arg = arg[:strings.Index(arg, "=")+1] + "[redacted]"
+		default:
+			arg = redact(arg)
+		}
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
+
+// operationPattern finds the operation name in a GraphQL document
+var operationPattern = regexp.MustCompile(`^\s*(query|mutation)\s+(\w+)`)
+
+// describeRequest returns the operation name of a GraphQL request body, e.g.
+// "query Project", and in debug mode its redacted variables
+func describeRequest(body []byte) string {
+	var req struct {
+		Query         string         `json:"query"`
+		OperationName string         `json:"operationName"`
+		Variables     map[string]any `json:"variables"`
+	}
+	if json.Unmarshal(body, &req) != nil {
+		return ""
+	}
+	op := req.OperationName
+	if m := operationPattern.FindStringSubmatch(req.Query); m != nil {
+		op = m[1] + " " + m[2]
+	}
+	if !debug || len(req.Variables) == 0 {
+		return op
+	}
+	vars, err := json.Marshal(redactVariables(req.Variables))
+	if err != nil {
+		return op
+	}
+	return op + " " + string(vars)
+}
@@ -0,0 +1,111 @@
+package ghc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// DefaultWorkers is the number of requests a Pool runs at the same time when
+// none is configured
+const DefaultWorkers = 4
+
+// Pool runs API requests in the background with a bounded number of them in
+// flight. Work queued after the context of the pool is cancelled is dropped,
+// and the context passed to running work is cancelled with it, so quitting a
+// view stops the requests it started.
+type Pool struct {
+	ctx context.Context
+	sem chan struct{}
+	wg  sync.WaitGroup
+}
+
+// NewPool creates a pool running up to workers functions at the same time,
+// or DefaultWorkers when workers is not positive
+func NewPool(ctx context.Context, workers int) *Pool {
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+	return &Pool{ctx: ctx, sem: make(chan struct{}, workers)}
+}
+
+// Go runs fn in its own goroutine once a worker is free. It does not block,
+// so running functions may queue more work.
+func (p *Pool) Go(fn func(ctx context.Context)) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		select {
+		case p.sem <- struct{}{}:
+		case <-p.ctx.Done():
+			return
+		}
+		defer func() { <-p.sem }()
+		if p.ctx.Err() != nil {
+			return
+		}
+		fn(p.ctx)
+	}()
+}
+
+// Wait blocks until every queued function has run or was dropped
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+// ProjectResult is a project loaded by FetchProjects with its fields and items
+type ProjectResult struct {
+	Owner   string
+	Number  int
+	Project *models.Project
+	Items   []models.Item
+	Err     error
+}
+
+// FetchProjects loads the projects of owners, and their fields and items,
+// through pool, calling done from the worker as each project finishes.
+// Closed projects are left out unless closed is set. An owner whose projects
+// cannot be listed is reported as a result without a number.
+func FetchProjects(pool *Pool, owners []string, closed bool, done func(ProjectResult)) {
+	for _, owner := range owners {
+		pool.Go(func(ctx context.Context) {
+			projects, err := GetOwnerProjectsContext(ctx, owner)
+			if err != nil {
+				done(ProjectResult{Owner: owner, Err: fmt.Errorf("listing the projects of %s: %w", ownerName(owner), err)})
+				return
+			}
+			for _, p := range projects {
+				if p.Closed && !closed {
+					continue
+				}
+				pool.Go(func(ctx context.Context) {
+					done(FetchProject(ctx, owner, int(p.Number)))
+				})
+			}
+		})
+	}
+}
+
+// FetchProject loads a project with its fields and items
+func FetchProject(ctx context.Context, owner string, number int) ProjectResult {
+	r := ProjectResult{Owner: owner, Number: number}
+	r.Project, r.Err = GetProjectContext(ctx, owner, number)
+	if r.Err != nil {
+		return r
+	}
+	r.Items, r.Err = GetItemsContext(ctx, r.Project.ID)
+	if r.Err != nil {
+		r.Err = fmt.Errorf("loading the items of %s/%d: %w", ownerName(owner), number, r.Err)
+	}
+	return r
+}
+
+// ownerName returns owner, or "@me" for the viewer
+func ownerName(owner string) string {
+	if owner == "" {
+		return "@me"
+	}
+	return owner
+}
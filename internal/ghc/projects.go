@@ -0,0 +1,431 @@
+package ghc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+type gqlNodes[T any] struct {
+	Nodes []T `json:"nodes"`
+}
+
+type gqlPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type gqlIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+type gqlProjectNode struct {
+	ID               string `json:"id"`
+	Number           int    `json:"number"`
+	Title            string `json:"title"`
+	URL              string `json:"url"`
+	Closed           bool   `json:"closed"`
+	Public           bool   `json:"public"`
+	ShortDescription string `json:"shortDescription"`
+	Readme           string `json:"readme"`
+	Owner            struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Fields gqlNodes[gqlFieldNode] `json:"fields"`
+}
+
+type gqlFieldNode struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	DataType string `json:"dataType"`
+	Options  []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Color       string `json:"color"`
+		Description string `json:"description"`
+	} `json:"options"`
+	Configuration struct {
+		Duration            int            `json:"duration"`
+		StartDay            int            `json:"startDay"`
+		Iterations          []gqlIteration `json:"iterations"`
+		CompletedIterations []gqlIteration `json:"completedIterations"`
+	} `json:"configuration"`
+}
+
+func (f gqlFieldNode) toModel() models.Field {
+	field := models.Field{
+		ID:                f.ID,
+		Name:              f.Name,
+		Type:              models.FieldType(f.DataType),
+		IterationDuration: f.Configuration.Duration,
+		IterationStartDay: time.Weekday(f.Configuration.StartDay % 7),
+	}
+	for _, o := range f.Options {
+		field.Options = append(field.Options, models.FieldOption(o))
+	}
+	for _, it := range append(f.Configuration.CompletedIterations, f.Configuration.Iterations...) {
+		field.Iterations = append(field.Iterations, models.Iteration(it))
+	}
+	sort.Slice(field.Iterations, func(i, j int) bool {
+		return field.Iterations[i].StartDate < field.Iterations[j].StartDate
+	})
+	return field
+}
+
+func (n gqlProjectNode) toModel() *models.Project {
+	p := &models.Project{
+		ID:               n.ID,
+		Number:           n.Number,
+		Owner:            n.Owner.Login,
+		Title:            n.Title,
+		ShortDescription: n.ShortDescription,
+		Readme:           n.Readme,
+		URL:              n.URL,
+		Closed:           n.Closed,
+		Public:           n.Public,
+	}
+	for _, f := range n.Fields.Nodes {
+		p.Fields = append(p.Fields, f.toModel())
+	}
+	return p
+}
+
+type gqlFieldName struct {
+	Name     string `json:"name"`
+	DataType string `json:"dataType"`
+}
+
+type gqlItemNode struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	IsArchived bool      `json:"isArchived"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Content    struct {
+		ID         string `json:"id"`
+		Number     int    `json:"number"`
+		Title      string `json:"title"`
+		Body       string `json:"body"`
+		URL        string `json:"url"`
+		State      string `json:"state"`
+		Repository struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+		Assignees gqlNodes[struct {
+			Login string `json:"login"`
+		}] `json:"assignees"`
+		Labels gqlNodes[struct {
+			Name string `json:"name"`
+		}] `json:"labels"`
+		Milestone *struct {
+			Title string `json:"title"`
+		} `json:"milestone"`
+		ClosedByPullRequestsReferences gqlNodes[struct {
+			URL string `json:"url"`
+		}] `json:"closedByPullRequestsReferences"`
+	} `json:"content"`
+	FieldValues gqlNodes[struct {
+		Text        string       `json:"text"`
+		Number      *float64     `json:"number"`
+		Date        string       `json:"date"`
+		Name        string       `json:"name"`
+		OptionID    string       `json:"optionId"`
+		Title       string       `json:"title"`
+		IterationID string       `json:"iterationId"`
+		Field       gqlFieldName `json:"field"`
+	}] `json:"fieldValues"`
+}
+
+func (n gqlItemNode) toModel() models.Item {
+	c := n.Content
+	item := models.Item{
+		ID:         n.ID,
+		Type:       n.Type,
+		ContentID:  c.ID,
+		Number:     c.Number,
+		Title:      c.Title,
+		Body:       c.Body,
+		URL:        c.URL,
+		Repository: c.Repository.NameWithOwner,
+		State:      c.State,
+		Archived:   n.IsArchived,
+		UpdatedAt:  n.UpdatedAt,
+		Values:     map[string]models.FieldValue{},
+	}
+	for _, a := range c.Assignees.Nodes {
+		item.Assignees = append(item.Assignees, a.Login)
+	}
+	for _, l := range c.Labels.Nodes {
+		item.Labels = append(item.Labels, l.Name)
+	}
+	if c.Milestone != nil {
+		item.Milestone = c.Milestone.Title
+	}
+	for _, pr := range c.ClosedByPullRequestsReferences.Nodes {
+		item.LinkedPRs = append(item.LinkedPRs, pr.URL)
+	}
+	for _, fv := range n.FieldValues.Nodes {
+		var v models.FieldValue
+		switch models.FieldType(fv.Field.DataType) {
+		case models.FieldTypeText:
+			v.Text = fv.Text
+		case models.FieldTypeNumber:
+			if fv.Number != nil {
+				v.Number = *fv.Number
+				v.Text = strconv.FormatFloat(*fv.Number, 'f', -1, 64)
+			}
+		case models.FieldTypeDate:
+			v.Text = fv.Date
+		case models.FieldTypeSingleSelect:
+			v.Text, v.OptionID = fv.Name, fv.OptionID
+		case models.FieldTypeIteration:
+			v.Text, v.IterationID = fv.Title, fv.IterationID
+		default:
+			continue
+		}
+		item.SetValue(fv.Field.Name, v)
+	}
+	return item
+}
+
+// GetProject returns the project with the given number together with its fields.
+// An empty owner or "@me" refers to the authenticated user.
+func GetProject(owner string, number int) (*models.Project, error) {
+	return GetProjectContext(context.Background(), owner, number)
+}
+
+// GetProjectContext is GetProject stopping when ctx is cancelled
+func GetProjectContext(ctx context.Context, owner string, number int) (*models.Project, error) {
+	if owner == "" || owner == "@me" {
+		user, err := GetWhoami()
+		if err != nil {
+			return nil, err
+		}
+		owner = user.Login
+	}
+	var resp struct {
+		RepositoryOwner *struct {
+			ProjectV2 *gqlProjectNode `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+	err := graphQLContext(ctx, gqlProject, map[string]interface{}{"owner": owner, "number": number}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.RepositoryOwner == nil || resp.RepositoryOwner.ProjectV2 == nil {
+		return nil, fmt.Errorf("project %s/%d %w", owner, number, ErrNotFound)
+	}
+	return resp.RepositoryOwner.ProjectV2.toModel(), nil
+}
+
+// GetItems returns every item of the project, following pagination
+func GetItems(projectID string) ([]models.Item, error) {
+	return GetItemsContext(context.Background(), projectID)
+}
+
+// GetItemsContext is GetItems stopping when ctx is cancelled
+func GetItemsContext(ctx context.Context, projectID string) ([]models.Item, error) {
+	var items []models.Item
+	vars := map[string]interface{}{"id": projectID, "cursor": nil}
+	for {
+		var resp struct {
+			Node struct {
+				Items struct {
+					PageInfo gqlPageInfo   `json:"pageInfo"`
+					Nodes    []gqlItemNode `json:"nodes"`
+				} `json:"items"`
+			} `json:"node"`
+		}
+		if err := graphQLContext(ctx, gqlProjectItems, vars, &resp); err != nil {
+			return nil, err
+		}
+		for _, n := range resp.Node.Items.Nodes {
+			items = append(items, n.toModel())
+		}
+		if !resp.Node.Items.PageInfo.HasNextPage {
+			return items, nil
+		}
+		vars["cursor"] = resp.Node.Items.PageInfo.EndCursor
+	}
+}
+
+// SetItemField sets the value of a custom field on a project item. A zero value clears the field.
+func SetItemField(projectID, itemID string, field models.Field, value models.FieldValue) error {
+	args := []string{"project", "item-edit", "--id", itemID, "--project-id", projectID, "--field-id", field.ID}
+	switch {
+	case value.IsZero():
+		args = append(args, "--clear")
+	case field.Type == models.FieldTypeText:
+		args = append(args, "--text", value.Text)
+	case field.Type == models.FieldTypeNumber:
+		args = append(args, "--number", strconv.FormatFloat(value.Number, 'f', -1, 64))
+	case field.Type == models.FieldTypeDate:
+		args = append(args, "--date", value.Text)
+	case field.Type == models.FieldTypeSingleSelect:
+		args = append(args, "--single-select-option-id", value.OptionID)
+	case field.Type == models.FieldTypeIteration:
+		args = append(args, "--iteration-id", value.IterationID)
+	default:
+		return fmt.Errorf("field %q of type %s cannot be edited", field.Name, field.Type)
+	}
+	_, err := newCommandArgs(args...).Exec()
+	return err
+}
+
+// GetProjectViews returns the views saved on a project on GitHub.
+// An empty owner or "@me" refers to the authenticated user.
+func GetProjectViews(owner string, number int) ([]models.ProjectView, error) {
+	if owner == "" || owner == "@me" {
+		user, err := GetWhoami()
+		if err != nil {
+			return nil, err
+		}
+		owner = user.Login
+	}
+	type fieldName struct {
+		Name string `json:"name"`
+	}
+	var resp struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				Views gqlNodes[struct {
+					Number       int                 `json:"number"`
+					Name         string              `json:"name"`
+					Layout       string              `json:"layout"`
+					Filter       string              `json:"filter"`
+					Fields       gqlNodes[fieldName] `json:"fields"`
+					SortByFields gqlNodes[struct {
+						Direction string    `json:"direction"`
+						Field     fieldName `json:"field"`
+					}] `json:"sortByFields"`
+					GroupByFields gqlNodes[fieldName] `json:"groupByFields"`
+				}] `json:"views"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+	err := graphQL(gqlProjectViews, map[string]interface{}{"owner": owner, "number": number}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.RepositoryOwner == nil || resp.RepositoryOwner.ProjectV2 == nil {
+		return nil, fmt.Errorf("project %s/%d not found", owner, number)
+	}
+
+	var views []models.ProjectView
+	for _, n := range resp.RepositoryOwner.ProjectV2.Views.Nodes {
+		v := models.ProjectView{Number: n.Number, Name: n.Name, Layout: n.Layout, Filter: n.Filter}
+		for _, f := range n.Fields.Nodes {
+			v.Fields = append(v.Fields, f.Name)
+		}
+		for _, s := range n.SortByFields.Nodes {
+			v.SortBy = append(v.SortBy, models.ViewSort{Field: s.Field.Name, Direction: s.Direction})
+		}
+		for _, f := range n.GroupByFields.Nodes {
+			v.GroupBy = append(v.GroupBy, f.Name)
+		}
+		views = append(views, v)
+	}
+	return views, nil
+}
+
+// SetItemArchived archives an item of a project, or restores it when archived is false
+func SetItemArchived(projectID, itemID string, archived bool) error {
+	query := gqlUnarchiveItem
+	if archived {
+		query = gqlArchiveItem
+	}
+	var resp struct{}
+	return graphQL(query, map[string]interface{}{
+		"input": map[string]interface{}{"projectId": projectID, "itemId": itemID},
+	}, &resp)
+}
+
+// EditLabels adds and removes labels on the issue or pull request behind an item
+func EditLabels(item models.Item, add, remove []string) error {
+	var kind string
+	switch item.Type {
+	case models.ItemTypeIssue:
+		kind = "issue"
+	case models.ItemTypePullRequest:
+		kind = "pr"
+	default:
+		return fmt.Errorf("%s: draft issues have no labels", item.Title)
+	}
+	args := []string{kind, "edit", item.URL}
+	if len(add) > 0 {
+		args = append(args, "--add-label", strings.Join(add, ","))
+	}
+	if len(remove) > 0 {
+		args = append(args, "--remove-label", strings.Join(remove, ","))
+	}
+	_, err := newCommandArgs(args...).Exec()
+	return err
+}
+
+// ProjectEdit lists the settings of a project to change; nil fields are left as they are
+type ProjectEdit struct {
+	Title            *string
+	ShortDescription *string
+	Readme           *string
+	Public           *bool
+	Closed           *bool
+}
+
+// Set changes a named project setting, see models.Project.Setting
+func (e *ProjectEdit) Set(setting, value string) error {
+	switch setting {
+	case models.SettingTitle:
+		e.Title = &value
+	case models.SettingDescription:
+		e.ShortDescription = &value
+	case models.SettingReadme:
+		e.Readme = &value
+	case models.SettingVisibility:
+		public := value == "public"
+		if !public && value != "private" {
+			return fmt.Errorf("invalid visibility %q, expected public or private", value)
+		}
+		e.Public = &public
+	case models.SettingState:
+		closed := value == "closed"
+		if !closed && value != "open" {
+			return fmt.Errorf("invalid state %q, expected open or closed", value)
+		}
+		e.Closed = &closed
+	default:
+		return fmt.Errorf("unknown project setting %q", setting)
+	}
+	return nil
+}
+
+// UpdateProject changes the settings of a project
+func UpdateProject(projectID string, edit ProjectEdit) error {
+	input := map[string]interface{}{"projectId": projectID}
+	for name, v := range map[string]interface{}{
+		"title":            edit.Title,
+		"shortDescription": edit.ShortDescription,
+		"readme":           edit.Readme,
+		"public":           edit.Public,
+		"closed":           edit.Closed,
+	} {
+		switch v := v.(type) {
+		case *string:
+			if v != nil {
+				input[name] = *v
+			}
+		case *bool:
+			if v != nil {
+				input[name] = *v
+			}
+		}
+	}
+	var resp struct{}
+	return graphQL(gqlUpdateProject, map[string]interface{}{"input": input}, &resp)
+}
@@ -10,3 +10,226 @@ var (
 	// QueryUserWhoami is a command to query the GitHub API for the current user
 	QueryUserWhoami = newCommand("api user")
 )
+
+const (
+	// gqlProjectFields selects a project and the definitions of its fields
+	gqlProjectFields = `
+fragment projectFields on ProjectV2 {
+  id number title url closed public shortDescription readme
+  owner { ... on Organization { login } ... on User { login } }
+  fields(first: 100) {
+    nodes {
+      ... on ProjectV2FieldCommon { id name dataType }
+      ... on ProjectV2SingleSelectField { options { id name color description } }
+      ... on ProjectV2IterationField {
+        configuration {
+          duration startDay
+          iterations { id title startDate duration }
+          completedIterations { id title startDate duration }
+        }
+      }
+    }
+  }
+}`
+
+	// gqlRateLimitFields selects the rate limit budget; the heavy queries include
+	// it so the transport in ratelimit.go sees the cost of each page
+	gqlRateLimitFields = `
+fragment rateLimitFields on RateLimit { cost limit remaining resetAt }`
+
+	// gqlPing is the cheapest query, used to check that the API answers
+	gqlPing = `
+query Ping {
+  rateLimit { ...rateLimitFields }
+  viewer { login }
+}` + gqlRateLimitFields
+
+	// gqlProject is a query for a project by owner login and number
+	gqlProject = `
+query Project($owner: String!, $number: Int!) {
+  rateLimit { ...rateLimitFields }
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner { projectV2(number: $number) { ...projectFields } }
+  }
+}` + gqlProjectFields + gqlRateLimitFields
+
+	// gqlItemFields selects an item, its content and its field values
+	gqlItemFields = `
+fragment fieldName on ProjectV2FieldConfiguration { ... on ProjectV2FieldCommon { name dataType } }
+fragment itemFields on ProjectV2Item {
+  id type isArchived updatedAt
+  content {
+    ... on DraftIssue { id title body assignees(first: 20) { nodes { login } } }
+    ... on Issue {
+      id number title body url state
+      repository { nameWithOwner }
+      assignees(first: 20) { nodes { login } }
+      labels(first: 20) { nodes { name } }
+      milestone { title }
+      closedByPullRequestsReferences(first: 10) { nodes { url } }
+    }
+    ... on PullRequest {
+      id number title body url state
+      repository { nameWithOwner }
+      assignees(first: 20) { nodes { login } }
+      labels(first: 20) { nodes { name } }
+      milestone { title }
+    }
+  }
+  fieldValues(first: 50) {
+    nodes {
+      ... on ProjectV2ItemFieldTextValue { text field { ...fieldName } }
+      ... on ProjectV2ItemFieldNumberValue { number field { ...fieldName } }
+      ... on ProjectV2ItemFieldDateValue { date field { ...fieldName } }
+      ... on ProjectV2ItemFieldSingleSelectValue { name optionId field { ...fieldName } }
+      ... on ProjectV2ItemFieldIterationValue { title iterationId field { ...fieldName } }
+    }
+  }
+}`
+
+	// gqlProjectItems is a paginated query for the items of a project
+	gqlProjectItems = `
+query ProjectItems($id: ID!, $cursor: String) {
+  rateLimit { ...rateLimitFields }
+  node(id: $id) {
+    ... on ProjectV2 {
+      items(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes { ...itemFields }
+      }
+    }
+  }
+}` + gqlItemFields + gqlRateLimitFields
+
+	// gqlProjectViews is a query for the saved views of a project
+	gqlProjectViews = `
+query ProjectViews($owner: String!, $number: Int!) {
+  rateLimit { ...rateLimitFields }
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        views(first: 50) {
+          nodes {
+            number name layout filter
+            fields(first: 50) { nodes { ... on ProjectV2FieldCommon { name } } }
+            sortByFields(first: 5) { nodes { direction field { ... on ProjectV2FieldCommon { name } } } }
+            groupByFields(first: 5) { nodes { ... on ProjectV2FieldCommon { name } } }
+          }
+        }
+      }
+    }
+  }
+}` + gqlRateLimitFields
+)
+
+const (
+	// gqlAddDraft adds a draft issue to a project
+	gqlAddDraft = `
+mutation AddDraft($input: AddProjectV2DraftIssueInput!) {
+  addProjectV2DraftIssue(input: $input) { projectItem { ...itemFields } }
+}` + gqlItemFields
+
+	// gqlUpdateDraft changes the title or body of a draft issue
+	gqlUpdateDraft = `
+mutation UpdateDraft($input: UpdateProjectV2DraftIssueInput!) {
+  updateProjectV2DraftIssue(input: $input) { draftIssue { id } }
+}`
+
+	// gqlConvertDraft turns a draft item into an issue of a repository
+	gqlConvertDraft = `
+mutation ConvertDraft($input: ConvertProjectV2DraftIssueItemToIssueInput!) {
+  convertProjectV2DraftIssueItemToIssue(input: $input) { item { ...itemFields } }
+}` + gqlItemFields
+
+	// gqlDeleteItem removes an item from a project
+	gqlDeleteItem = `
+mutation DeleteItem($input: DeleteProjectV2ItemInput!) {
+  deleteProjectV2Item(input: $input) { deletedItemId }
+}`
+
+	// gqlAddItem adds an existing issue or pull request to a project
+	gqlAddItem = `
+mutation AddItem($input: AddProjectV2ItemByIdInput!) {
+  addProjectV2ItemById(input: $input) { item { ...itemFields } }
+}` + gqlItemFields
+
+	// gqlContentFields selects an issue or pull request that can be added to a project
+	gqlContentFields = `
+fragment contentFields on Node {
+  __typename
+  ... on Issue { id number title url state repository { nameWithOwner } }
+  ... on PullRequest { id number title url state repository { nameWithOwner } }
+}`
+
+	// gqlResource is a query for the issue or pull request behind a URL
+	gqlResource = `
+query Resource($url: URI!) {
+  resource(url: $url) { ...contentFields }
+}` + gqlContentFields
+
+	// gqlSearchContent is a paginated search for issues and pull requests
+	gqlSearchContent = `
+query SearchContent($query: String!, $first: Int!, $cursor: String) {
+  rateLimit { ...rateLimitFields }
+  search(query: $query, type: ISSUE, first: $first, after: $cursor) {
+    pageInfo { hasNextPage endCursor }
+    nodes { ...contentFields }
+  }
+}` + gqlContentFields + gqlRateLimitFields
+
+	// gqlRepositoryID is a query for the node ID of a repository
+	gqlRepositoryID = `
+query RepositoryID($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) { id }
+}`
+
+	// gqlArchiveItem hides an item from the views of its project
+	gqlArchiveItem = `
+mutation ArchiveItem($input: ArchiveProjectV2ItemInput!) {
+  archiveProjectV2Item(input: $input) { item { id } }
+}`
+
+	// gqlUnarchiveItem restores an archived item
+	gqlUnarchiveItem = `
+mutation UnarchiveItem($input: UnarchiveProjectV2ItemInput!) {
+  unarchiveProjectV2Item(input: $input) { item { id } }
+}`
+
+	// gqlUpdateProject changes the settings of a project
+	gqlUpdateProject = `
+mutation UpdateProject($input: UpdateProjectV2Input!) {
+  updateProjectV2(input: $input) { projectV2 { id } }
+}`
+
+	// gqlFieldResult selects a field returned by a field mutation
+	gqlFieldResult = `
+fragment fieldResult on ProjectV2FieldConfiguration {
+  ... on ProjectV2FieldCommon { id name dataType }
+  ... on ProjectV2SingleSelectField { options { id name color description } }
+  ... on ProjectV2IterationField {
+    configuration {
+      duration startDay
+      iterations { id title startDate duration }
+      completedIterations { id title startDate duration }
+    }
+  }
+}`
+
+	// gqlCreateField adds a field to a project
+	gqlCreateField = `
+mutation CreateField($input: CreateProjectV2FieldInput!) {
+  createProjectV2Field(input: $input) { projectV2Field { ...fieldResult } }
+}` + gqlFieldResult
+
+	// gqlUpdateField renames a field or replaces its options
+	gqlUpdateField = `
+mutation UpdateField($input: UpdateProjectV2FieldInput!) {
+  updateProjectV2Field(input: $input) { projectV2Field { ...fieldResult } }
+}` + gqlFieldResult
+
+	// gqlDeleteField removes a field and its values from a project
+	gqlDeleteField = `
+mutation DeleteField($input: DeleteProjectV2FieldInput!) {
+  deleteProjectV2Field(input: $input) { projectV2Field { ... on ProjectV2FieldCommon { id } } }
+}`
+)
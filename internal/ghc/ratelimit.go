@@ -0,0 +1,253 @@
+package ghc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRetries bounds the retries of a request that failed with a server
+	// error or a rate limit
+	maxRetries = 5
+	// lowBudget is the remaining GraphQL budget below which requests are
+	// spread out over the time left until the reset
+	lowBudget = 200
+	// maxWait bounds a single wait for the budget to reset; longer waits fail
+	// instead of leaving the command hanging
+	maxWait = 15 * time.Minute
+)
+
+// Budget is the GraphQL rate limit budget last reported by GitHub
+type Budget struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	// Cost is the cost of the last query that reported it
+	Cost int
+}
+
+// String describes the budget, e.g. "API 4,812/5,000 (resets 14:05)"
+func (b Budget) String() string {
+	return fmt.Sprintf("API %s/%s (resets %s)", thousands(b.Remaining), thousands(b.Limit), b.Reset.Local().Format("15:04"))
+}
+
+var (
+	budgetMu sync.Mutex
+	budget   Budget
+)
+
+// RateLimit returns the budget last reported by GitHub; ok is false until a
+// response reported one
+func RateLimit() (b Budget, ok bool) {
+	budgetMu.Lock()
+	defer budgetMu.Unlock()
+	return budget, budget.Limit > 0
+}
+
+// rateLimitTransport throttles GraphQL requests as the budget runs low and
+// retries those that fail with a server error or a rate limit
+type rateLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	ctx := req.Context()
+	desc := req.Method + " " + req.URL.Path
+	if op := describeRequest(body); op != "" {
+		desc += " " + op
+	}
+	for attempt := 0; ; attempt++ {
+		if d := throttleDelay(time.Now()); d > 0 {
+			b, _ := RateLimit()
+			logger.Printf("throttling for %s, %s", d.Round(time.Millisecond), b)
+			if err := sleep(ctx, d); err != nil {
+				return nil, err
+			}
+		}
+
+		r := req.Clone(ctx)
+		if body != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+		}
+		start := time.Now()
+		resp, err := t.base.RoundTrip(r)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+
+		cost, limited := recordBudget(resp.Header, data)
+		line := fmt.Sprintf("%s: %d in %s", desc, resp.StatusCode, time.Since(start).Round(time.Millisecond))
+		if cost > 0 {
+			line += fmt.Sprintf(", cost %d", cost)
+		}
+		if b, ok := RateLimit(); ok {
+			line += ", " + b.String()
+		}
+		logger.Print(line)
+
+		wait, retry := retryDelay(resp, data, limited, attempt)
+		// A mutation that hit a server error may have been applied, so only
+		// rate limits, which reject the request up front, are retried for them
+		if retry && resp.StatusCode >= 500 && isMutation(body) {
+			retry = false
+		}
+		if !retry || attempt >= maxRetries || wait > maxWait {
+			return resp, nil
+		}
+		logger.Printf("retrying in %s (%d of %d)", wait.Round(time.Millisecond), attempt+1, maxRetries)
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// recordBudget updates the budget from the X-RateLimit headers and the
+// rateLimit field of a GraphQL response. It returns the cost of the query, or
+// 0 when the response did not report it, and whether the response is a
+// GraphQL RATE_LIMITED error.
+func recordBudget(h http.Header, data []byte) (cost int, limited bool) {
+	var gql struct {
+		Data struct {
+			RateLimit *struct {
+				Cost      int       `json:"cost"`
+				Limit     int       `json:"limit"`
+				Remaining int       `json:"remaining"`
+				ResetAt   time.Time `json:"resetAt"`
+			} `json:"rateLimit"`
+		} `json:"data"`
+		Errors []struct {
+			Type string `json:"type"`
+		} `json:"errors"`
+	}
+	json.Unmarshal(data, &gql)
+
+	budgetMu.Lock()
+	defer budgetMu.Unlock()
+	if resource := h.Get("X-RateLimit-Resource"); resource == "" || resource == "graphql" {
+		limit, errLimit := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+		remaining, errRemaining := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+		reset, errReset := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+		if errLimit == nil && errRemaining == nil && errReset == nil {
+			budget.Limit, budget.Remaining, budget.Reset = limit, remaining, time.Unix(reset, 0)
+		}
+	}
+	if rl := gql.Data.RateLimit; rl != nil {
+		budget = Budget{Limit: rl.Limit, Remaining: rl.Remaining, Reset: rl.ResetAt, Cost: rl.Cost}
+		cost = rl.Cost
+	}
+	for _, e := range gql.Errors {
+		if e.Type == "RATE_LIMITED" {
+			limited = true
+		}
+	}
+	return cost, limited
+}
+
+// isMutation reports whether a GraphQL request body holds a mutation
+func isMutation(body []byte) bool {
+	var req struct {
+		Query string `json:"query"`
+	}
+	json.Unmarshal(body, &req)
+	return strings.HasPrefix(strings.TrimSpace(req.Query), "mutation")
+}
+
+// rateLimitOutput reports whether the error output of gh tells of a rate
+// limit, and whether it is a secondary one
+func rateLimitOutput(stderr string) (limited, secondary bool) {
+	s := strings.ToLower(stderr)
+	secondary = strings.Contains(s, "secondary rate limit") || strings.Contains(s, "submitted too quickly")
+	return secondary || strings.Contains(s, "rate limit exceeded"), secondary
+}
+
+// throttleDelay returns how long to wait before the next request so that the
+// remaining budget lasts until it resets
+func throttleDelay(now time.Time) time.Duration {
+	b, ok := RateLimit()
+	if !ok || !b.Reset.After(now) || b.Remaining >= lowBudget {
+		return 0
+	}
+	left := b.Reset.Sub(now)
+	if b.Remaining <= 0 {
+		return min(left+time.Second, maxWait)
+	}
+	return left / time.Duration(b.Remaining)
+}
+
+// retryDelay decides whether a response is worth retrying and how long to
+// wait first: server errors and secondary rate limits back off exponentially,
+// honouring Retry-After, and an exhausted budget waits for its reset
+func retryDelay(resp *http.Response, data []byte, limited bool, attempt int) (time.Duration, bool) {
+	switch {
+	case resp.StatusCode == http.StatusBadGateway, resp.StatusCode == http.StatusServiceUnavailable, resp.StatusCode == http.StatusGatewayTimeout:
+		return backoff(attempt), true
+	case resp.StatusCode == http.StatusForbidden, resp.StatusCode == http.StatusTooManyRequests, limited:
+	default:
+		return 0, false
+	}
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(s) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" || limited {
+		if b, ok := RateLimit(); ok && b.Reset.After(time.Now()) {
+			return time.Until(b.Reset) + time.Second, true
+		}
+	}
+	// GitHub asks to wait at least a minute after hitting a secondary limit
+	if strings.Contains(strings.ToLower(string(data)), "secondary rate limit") {
+		return max(backoff(attempt), time.Minute), true
+	}
+	return 0, false
+}
+
+// backoff returns the delay before retry attempt n: one second doubling with
+// every attempt, with half of it randomized so concurrent workers spread out
+func backoff(attempt int) time.Duration {
+	d := time.Second << min(attempt, 6)
+	return d/2 + rand.N(d/2)
+}
+
+// sleep waits for d or until ctx is cancelled
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// thousands formats n with comma separators
+func thousands(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0 && s[i-1] != '-'; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,323 @@
+// Package journal records every change gh-pm makes on GitHub in a local file
+// so that `gh pm undo` can revert it later.
+package journal
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+const fileName = "journal.jsonl"
+
+// Kinds of recorded changes
+const (
+	// KindField is a project field value set on an item
+	KindField = "field"
+	// KindLabels is labels added to or removed from an issue or pull request
+	KindLabels = "labels"
+	// KindItemAdd is an item added to a project, such as a new draft issue
+	KindItemAdd = "item-add"
+	// KindDraftEdit is the title or body of a draft issue changed from From to To
+	KindDraftEdit = "draft-edit"
+	// KindDraftConvert is a draft issue converted to an issue of the repository in To; it cannot be undone
+	KindDraftConvert = "draft-convert"
+	// KindItemArchive is an item archived in its project
+	KindItemArchive = "item-archive"
+	// KindItemUnarchive is an archived item restored in its project
+	KindItemUnarchive = "item-unarchive"
+	// KindProjectCreate is a project created by gh-pm
+	KindProjectCreate = "project-create"
+	// KindProjectDelete is a deleted project; it cannot be undone
+	KindProjectDelete = "project-delete"
+	// KindProjectEdit is a project setting changed from From to To
+	KindProjectEdit = "project-edit"
+	// KindFieldCreate is a field added to a project
+	KindFieldCreate = "field-create"
+	// KindFieldUpdate is a field renamed or given new options, OldField is its previous definition
+	KindFieldUpdate = "field-update"
+	// KindFieldDelete is a field removed from a project with its values; it cannot be undone
+	KindFieldDelete = "field-delete"
+	// kindUndone marks a batch as reverted by `gh pm undo`
+	kindUndone = "undone"
+)
+
+// Entry is one recorded change
+type Entry struct {
+	Batch   string    `json:"batch"`
+	Command string    `json:"command,omitempty"`
+	At      time.Time `json:"at"`
+	Kind    string    `json:"kind"`
+
+	ProjectID string `json:"projectId,omitempty"`
+	// Owner and Number locate the project, set for changes to the project itself
+	Owner  string `json:"owner,omitempty"`
+	Number int    `json:"number,omitempty"`
+
+	// Setting, From and To describe a KindProjectEdit or KindDraftEdit change
+	Setting string `json:"setting,omitempty"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+
+	OldField *models.Field `json:"oldField,omitempty"`
+
+	Item   models.Item        `json:"item"`
+	Field  *models.Field      `json:"field,omitempty"`
+	Before *models.FieldValue `json:"before,omitempty"`
+	After  *models.FieldValue `json:"after,omitempty"`
+
+	// AddedLabels and RemovedLabels only list labels whose presence actually changed
+	AddedLabels   []string `json:"addedLabels,omitempty"`
+	RemovedLabels []string `json:"removedLabels,omitempty"`
+}
+
+// Batch groups the changes made by one command or one action in a view
+type Batch struct {
+	ID      string
+	Command string
+	At      time.Time
+	Entries []Entry
+}
+
+// Path returns the location of the journal file
+func Path() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-pm", fileName), nil
+}
+
+// mu serializes appends from concurrent bulk edits
+var mu sync.Mutex
+
+// Recorder appends the changes of a single batch to the journal. A change has
+// already happened on GitHub when it is recorded, so failing to record it does
+// not fail the change; the first error is kept for Err instead. A nil Recorder
+// records nothing, which is what dry runs use.
+type Recorder struct {
+	batch   string
+	command string
+	err     error
+}
+
+// Begin starts a batch for the changes made by command, e.g. "project item edit"
+func Begin(command string) *Recorder {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return &Recorder{
+		batch:   time.Now().UTC().Format("20060102T150405") + "-" + hex.EncodeToString(b),
+		command: command,
+	}
+}
+
+// Field records a field of item changing from before to after
+func (r *Recorder) Field(projectID string, item models.Item, field models.Field, before, after models.FieldValue) {
+	r.append(Entry{
+		Kind:      KindField,
+		ProjectID: projectID,
+		Item:      trim(item),
+		Field:     &field,
+		Before:    &before,
+		After:     &after,
+	})
+}
+
+// Labels records labels added to and removed from item of a project, given
+// the labels the item had before the change
+func (r *Recorder) Labels(projectID string, item models.Item, add, remove []string) {
+	var added, removed []string
+	for _, l := range add {
+		if !slices.Contains(item.Labels, l) {
+			added = append(added, l)
+		}
+	}
+	for _, l := range remove {
+		if slices.Contains(item.Labels, l) {
+			removed = append(removed, l)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	r.append(Entry{Kind: KindLabels, ProjectID: projectID, Item: trim(item), AddedLabels: added, RemovedLabels: removed})
+}
+
+// ItemAdded records an item added to a project
+func (r *Recorder) ItemAdded(projectID string, item models.Item) {
+	r.append(Entry{Kind: KindItemAdd, ProjectID: projectID, Item: trim(item)})
+}
+
+// DraftEdited records the title or body of a draft issue changing
+func (r *Recorder) DraftEdited(projectID string, item models.Item, setting, from, to string) {
+	r.append(Entry{Kind: KindDraftEdit, ProjectID: projectID, Item: trim(item), Setting: setting, From: from, To: to})
+}
+
+// DraftConverted records a draft issue converted to an issue of repo
+func (r *Recorder) DraftConverted(projectID string, item models.Item, repo string) {
+	r.append(Entry{Kind: KindDraftConvert, ProjectID: projectID, Item: trim(item), To: repo})
+}
+
+// Archived records item being archived, or restored when archived is false
+func (r *Recorder) Archived(projectID string, item models.Item, archived bool) {
+	kind := KindItemUnarchive
+	if archived {
+		kind = KindItemArchive
+	}
+	r.append(Entry{Kind: kind, ProjectID: projectID, Item: trim(item)})
+}
+
+// ProjectCreated records a project created by gh-pm
+func (r *Recorder) ProjectCreated(owner string, number int, projectID string) {
+	r.append(Entry{Kind: KindProjectCreate, Owner: owner, Number: number, ProjectID: projectID})
+}
+
+// ProjectDeleted records a deleted project
+func (r *Recorder) ProjectDeleted(owner string, number int, projectID string) {
+	r.append(Entry{Kind: KindProjectDelete, Owner: owner, Number: number, ProjectID: projectID})
+}
+
+// ProjectEdited records a project setting changing from one value to another
+func (r *Recorder) ProjectEdited(p *models.Project, setting, from, to string) {
+	r.append(Entry{Kind: KindProjectEdit, ProjectID: p.ID, Owner: p.Owner, Number: p.Number, Setting: setting, From: from, To: to})
+}
+
+// FieldCreated records a field added to a project
+func (r *Recorder) FieldCreated(p *models.Project, field models.Field) {
+	r.append(Entry{Kind: KindFieldCreate, ProjectID: p.ID, Owner: p.Owner, Number: p.Number, Field: &field})
+}
+
+// FieldUpdated records the definition of a field changing from before to after
+func (r *Recorder) FieldUpdated(p *models.Project, before, after models.Field) {
+	r.append(Entry{Kind: KindFieldUpdate, ProjectID: p.ID, Owner: p.Owner, Number: p.Number, OldField: &before, Field: &after})
+}
+
+// FieldDeleted records a field removed from a project
+func (r *Recorder) FieldDeleted(p *models.Project, field models.Field) {
+	r.append(Entry{Kind: KindFieldDelete, ProjectID: p.ID, Owner: p.Owner, Number: p.Number, Field: &field})
+}
+
+func (r *Recorder) append(e Entry) {
+	if r == nil {
+		return
+	}
+	e.Batch = r.batch
+	e.Command = r.command
+	e.At = time.Now()
+	if err := appendEntries(e); err != nil {
+		mu.Lock()
+		if r.err == nil {
+			r.err = err
+		}
+		mu.Unlock()
+	}
+}
+
+// Err returns the first error met while recording
+func (r *Recorder) Err() error {
+	if r == nil {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return r.err
+}
+
+// trim keeps the parts of an item needed to describe and revert a change
+func trim(item models.Item) models.Item {
+	return models.Item{
+		ID:         item.ID,
+		Type:       item.Type,
+		ContentID:  item.ContentID,
+		Number:     item.Number,
+		Title:      item.Title,
+		URL:        item.URL,
+		Repository: item.Repository,
+	}
+}
+
+func appendEntries(entries ...Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// Batches returns the batches that have not been undone, newest first
+func Batches() ([]Batch, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var batches []Batch
+	index := map[string]int{}
+	undone := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// A line cut short by a crash should not hide the rest of the journal
+			continue
+		}
+		if e.Kind == kindUndone {
+			undone[e.Batch] = true
+			continue
+		}
+		i, ok := index[e.Batch]
+		if !ok {
+			i = len(batches)
+			index[e.Batch] = i
+			batches = append(batches, Batch{ID: e.Batch, Command: e.Command, At: e.At})
+		}
+		batches[i].Entries = append(batches[i].Entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	batches = slices.DeleteFunc(batches, func(b Batch) bool { return undone[b.ID] })
+	slices.Reverse(batches)
+	return batches, nil
+}
+
+// MarkUndone records that a batch was reverted so it is not undone twice
+func MarkUndone(batch string) error {
+	return appendEntries(Entry{Batch: batch, At: time.Now(), Kind: kindUndone})
+}
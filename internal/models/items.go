@@ -0,0 +1,365 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType mirrors the ProjectV2FieldType enum of the GitHub GraphQL API
+type FieldType string
+
+const (
+	FieldTypeTitle        FieldType = "TITLE"
+	FieldTypeText         FieldType = "TEXT"
+	FieldTypeNumber       FieldType = "NUMBER"
+	FieldTypeDate         FieldType = "DATE"
+	FieldTypeSingleSelect FieldType = "SINGLE_SELECT"
+	FieldTypeIteration    FieldType = "ITERATION"
+)
+
+// Item types reported by the ProjectV2ItemType enum
+const (
+	ItemTypeIssue       = "ISSUE"
+	ItemTypePullRequest = "PULL_REQUEST"
+	ItemTypeDraftIssue  = "DRAFT_ISSUE"
+)
+
+// StatusField is the name of the single select field GitHub creates on every project
+const StatusField = "Status"
+
+// Project is a Projects V2 board together with its field definitions
+type Project struct {
+	ID               string
+	Number           int
+	Owner            string
+	Title            string
+	ShortDescription string
+	Readme           string
+	URL              string
+	Closed           bool
+	Public           bool
+	Fields           []Field
+}
+
+// Field returns the field with the given name, or nil if the project has none
+func (p *Project) Field(name string) *Field {
+	for i := range p.Fields {
+		if p.Fields[i].Name == name {
+			return &p.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Settings of a project shown and changed by name, see Project.Setting
+const (
+	SettingTitle       = "title"
+	SettingDescription = "description"
+	SettingReadme      = "readme"
+	// SettingVisibility is "public" or "private"
+	SettingVisibility = "visibility"
+	// SettingState is "open" or "closed"
+	SettingState = "state"
+)
+
+// Settings lists the names of the project settings in display order
+var Settings = []string{SettingTitle, SettingDescription, SettingReadme, SettingVisibility, SettingState}
+
+// Setting returns the value of a named project setting
+func (p *Project) Setting(name string) string {
+	switch name {
+	case SettingTitle:
+		return p.Title
+	case SettingDescription:
+		return p.ShortDescription
+	case SettingReadme:
+		return p.Readme
+	case SettingVisibility:
+		if p.Public {
+			return "public"
+		}
+		return "private"
+	case SettingState:
+		if p.Closed {
+			return "closed"
+		}
+		return "open"
+	}
+	return ""
+}
+
+// SetSetting changes a named project setting, see Setting for the values
+func (p *Project) SetSetting(name, value string) {
+	switch name {
+	case SettingTitle:
+		p.Title = value
+	case SettingDescription:
+		p.ShortDescription = value
+	case SettingReadme:
+		p.Readme = value
+	case SettingVisibility:
+		p.Public = value == "public"
+	case SettingState:
+		p.Closed = value == "closed"
+	}
+}
+
+// EditableFields returns the custom fields whose values can be set on an item
+func (p *Project) EditableFields() []Field {
+	fields := make([]Field, 0, len(p.Fields))
+	for _, f := range p.Fields {
+		if f.Editable() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// ProjectView is a view saved on a project on GitHub
+type ProjectView struct {
+	Number int
+	Name   string
+	// Layout is BOARD_LAYOUT, TABLE_LAYOUT or ROADMAP_LAYOUT
+	Layout string
+	Filter string
+	// Fields are the names of the visible fields in order
+	Fields  []string
+	SortBy  []ViewSort
+	GroupBy []string
+}
+
+// ViewSort is a sort criterion of a view; Direction is ASC or DESC
+type ViewSort struct {
+	Field     string
+	Direction string
+}
+
+// Field is a Projects V2 field definition
+type Field struct {
+	ID         string
+	Name       string
+	Type       FieldType
+	Options    []FieldOption
+	Iterations []Iteration
+
+	// IterationDuration is the length in days of new iterations of an iteration field
+	IterationDuration int
+	// IterationStartDay is the weekday new iterations start on
+	IterationStartDay time.Weekday
+}
+
+// Editable reports whether item values for the field can be set through the API
+func (f Field) Editable() bool {
+	switch f.Type {
+	case FieldTypeText, FieldTypeNumber, FieldTypeDate, FieldTypeSingleSelect, FieldTypeIteration:
+		return true
+	}
+	return false
+}
+
+// Option returns the single select option with the given name
+func (f Field) Option(name string) (FieldOption, bool) {
+	for _, o := range f.Options {
+		if o.Name == name {
+			return o, true
+		}
+	}
+	return FieldOption{}, false
+}
+
+// Iteration returns the iteration with the given title
+func (f Field) Iteration(title string) (Iteration, bool) {
+	for _, it := range f.Iterations {
+		if it.Title == title {
+			return it, true
+		}
+	}
+	return Iteration{}, false
+}
+
+// IterationAt returns the iteration containing day moved by offset iterations,
+// so an offset of -1 is the previous iteration and 1 the next one. Iterations
+// must be ordered by start date.
+func (f Field) IterationAt(day time.Time, offset int) (Iteration, bool) {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	i := -1
+	for n, it := range f.Iterations {
+		if !day.Before(it.Start()) && day.Before(it.End()) {
+			i = n + offset
+			break
+		}
+		// Between iterations the upcoming one counts as next
+		if it.Start().After(day) {
+			if offset <= 0 {
+				return Iteration{}, false
+			}
+			i = n + offset - 1
+			break
+		}
+	}
+	if i < 0 || i >= len(f.Iterations) {
+		return Iteration{}, false
+	}
+	return f.Iterations[i], true
+}
+
+// ParseValue converts text typed by a user into a value of the field. Options
+// and iterations are matched by name, ignoring case; iterations also accept
+// @previous, @current and @next, and dates accept @today. An empty string
+// clears the field.
+func (f Field) ParseValue(s string, now time.Time) (FieldValue, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return FieldValue{}, nil
+	}
+	switch f.Type {
+	case FieldTypeText:
+		return FieldValue{Text: s}, nil
+	case FieldTypeNumber:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return FieldValue{}, fmt.Errorf("%s: %q is not a number", f.Name, s)
+		}
+		return FieldValue{Text: strconv.FormatFloat(n, 'f', -1, 64), Number: n}, nil
+	case FieldTypeDate:
+		if strings.EqualFold(s, "@today") {
+			s = now.Format(time.DateOnly)
+		}
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return FieldValue{}, fmt.Errorf("%s: %q is not a date like 2006-01-02", f.Name, s)
+		}
+		return FieldValue{Text: s}, nil
+	case FieldTypeSingleSelect:
+		names := make([]string, 0, len(f.Options))
+		for _, o := range f.Options {
+			if strings.EqualFold(o.Name, s) {
+				return FieldValue{Text: o.Name, OptionID: o.ID}, nil
+			}
+			names = append(names, o.Name)
+		}
+		return FieldValue{}, fmt.Errorf("%s has no option %q, expected one of %s", f.Name, s, strings.Join(names, ", "))
+	case FieldTypeIteration:
+		offsets := map[string]int{"@previous": -1, "@current": 0, "@next": 1}
+		if offset, ok := offsets[strings.ToLower(s)]; ok {
+			it, ok := f.IterationAt(now, offset)
+			if !ok {
+				return FieldValue{}, fmt.Errorf("%s has no %s iteration", f.Name, s[1:])
+			}
+			return FieldValue{Text: it.Title, IterationID: it.ID}, nil
+		}
+		for _, it := range f.Iterations {
+			if strings.EqualFold(it.Title, s) {
+				return FieldValue{Text: it.Title, IterationID: it.ID}, nil
+			}
+		}
+		return FieldValue{}, fmt.Errorf("%s has no iteration %q", f.Name, s)
+	}
+	return FieldValue{}, fmt.Errorf("field %q of type %s cannot be edited", f.Name, f.Type)
+}
+
+// FieldOption is an option of a single select field
+type FieldOption struct {
+	ID          string
+	Name        string
+	Color       string
+	Description string
+}
+
+// Iteration is one iteration of an iteration field
+type Iteration struct {
+	ID        string
+	Title     string
+	StartDate string
+	Duration  int
+}
+
+// Start returns the first day of the iteration
+func (it Iteration) Start() time.Time {
+	t, _ := time.Parse(time.DateOnly, it.StartDate)
+	return t
+}
+
+// End returns the first day after the iteration
+func (it Iteration) End() time.Time {
+	return it.Start().AddDate(0, 0, it.Duration)
+}
+
+// Item is a card on a Projects V2 board
+type Item struct {
+	ID         string
+	Type       string
+	ContentID  string
+	Number     int
+	Title      string
+	Body       string
+	URL        string
+	Repository string
+	State      string
+	Assignees  []string
+	Labels     []string
+	Milestone  string
+	LinkedPRs  []string
+	Archived   bool
+	UpdatedAt  time.Time
+
+	// Values holds the custom field values keyed by field name
+	Values map[string]FieldValue
+}
+
+// Value returns the display value of the named field, or "" when unset
+func (i Item) Value(field string) string {
+	return i.Values[field].Text
+}
+
+// Status returns the value of the Status field
+func (i Item) Status() string {
+	return i.Value(StatusField)
+}
+
+// Ref returns a short reference such as owner/repo#12, or "Draft" for draft issues
+func (i Item) Ref() string {
+	if i.Type == ItemTypeDraftIssue {
+		return "Draft"
+	}
+	return i.Repository + "#" + strconv.Itoa(i.Number)
+}
+
+// SetValue records v as the value of field, removing it when v is empty
+func (i *Item) SetValue(field string, v FieldValue) {
+	if i.Values == nil {
+		i.Values = map[string]FieldValue{}
+	}
+	if v.IsZero() {
+		delete(i.Values, field)
+		return
+	}
+	i.Values[field] = v
+}
+
+// FieldValue is the value of a custom field on an item. Text always holds the
+// display value; the ID fields are set for single select and iteration fields.
+type FieldValue struct {
+	Text        string  `json:"text"`
+	Number      float64 `json:"number,omitempty"`
+	OptionID    string  `json:"optionId,omitempty"`
+	IterationID string  `json:"iterationId,omitempty"`
+}
+
+// IsZero reports whether the value is unset
+func (v FieldValue) IsZero() bool {
+	return v == FieldValue{}
+}
+
+// Same reports whether v and o hold the same value, comparing option and
+// iteration IDs rather than their display text, which can be renamed
+func (v FieldValue) Same(o FieldValue) bool {
+	switch {
+	case v.OptionID != "" || o.OptionID != "":
+		return v.OptionID == o.OptionID
+	case v.IterationID != "" || o.IterationID != "":
+		return v.IterationID == o.IterationID
+	}
+	return v.Text == o.Text && v.Number == o.Number
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIterationAt(t *testing.T) {
+	field := Field{Name: "Sprint", Type: FieldTypeIteration, Iterations: []Iteration{
+		{ID: "i1", Title: "Sprint 1", StartDate: "2025-03-03", Duration: 7},
+		{ID: "i2", Title: "Sprint 2", StartDate: "2025-03-10", Duration: 7},
+		// A week off between Sprint 2 and Sprint 3
+		{ID: "i3", Title: "Sprint 3", StartDate: "2025-03-24", Duration: 14},
+	}}
+	day := func(s string) time.Time {
+		t, _ := time.Parse(time.DateOnly, s)
+		return t.Add(15 * time.Hour)
+	}
+
+	tests := []struct {
+		day    string
+		offset int
+		want   string
+	}{
+		{"2025-03-03", 0, "Sprint 1"},
+		{"2025-03-09", 0, "Sprint 1"},
+		{"2025-03-10", 0, "Sprint 2"},
+		{"2025-03-12", -1, "Sprint 1"},
+		{"2025-03-12", 1, "Sprint 3"},
+		{"2025-03-12", 2, ""},
+		{"2025-03-03", -1, ""},
+		// Between iterations nothing is current and the upcoming one is next
+		{"2025-03-18", 0, ""},
+		{"2025-03-18", -1, ""},
+		{"2025-03-18", 1, "Sprint 3"},
+		{"2025-04-06", 0, "Sprint 3"},
+		{"2025-04-07", 0, ""},
+		{"2025-03-01", 1, "Sprint 1"},
+		{"2025-03-01", 2, "Sprint 2"},
+	}
+	for _, tt := range tests {
+		it, ok := field.IterationAt(day(tt.day), tt.offset)
+		if got := it.Title; got != tt.want || ok != (tt.want != "") {
+			t.Errorf("IterationAt(%s, %d) = %q, %v, want %q", tt.day, tt.offset, got, ok, tt.want)
+		}
+	}
+}
+
+func TestIterationAtTimeZone(t *testing.T) {
+	field := Field{Iterations: []Iteration{
+		{Title: "Sprint 1", StartDate: "2025-03-03", Duration: 7},
+		{Title: "Sprint 2", StartDate: "2025-03-10", Duration: 7},
+	}}
+	// The calendar day of the given time counts, whatever its zone
+	tokyo := time.FixedZone("JST", 9*60*60)
+	it, ok := field.IterationAt(time.Date(2025, 3, 10, 1, 0, 0, 0, tokyo), 0)
+	if !ok || it.Title != "Sprint 2" {
+		t.Errorf("IterationAt(2025-03-10 01:00 JST) = %q, %v, want Sprint 2", it.Title, ok)
+	}
+}
@@ -0,0 +1,399 @@
+package spec
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// Action is the kind of change a step of a plan makes
+type Action string
+
+const (
+	ActionCreate Action = "+"
+	ActionUpdate Action = "~"
+	ActionDelete Action = "-"
+	// ActionManual is a difference that cannot be applied through the API
+	ActionManual Action = "!"
+)
+
+// Step is one change of a plan
+type Step struct {
+	Action  Action
+	Summary string
+	Details []string
+	apply   func(rec *journal.Recorder) error
+}
+
+// Plan lists the changes that make a project match a spec
+type Plan struct {
+	Owner string
+	// Project is nil when the spec creates a new project
+	Project *models.Project
+	Spec    *Spec
+	Steps   []Step
+	// Kept lists the project fields missing from the spec that are kept because pruning is off
+	Kept []string
+}
+
+// newProjectFields are the fields GitHub adds to a new project, besides the built-in ones
+var newProjectFields = []models.Field{{
+	Name: models.StatusField,
+	Type: models.FieldTypeSingleSelect,
+	Options: []models.FieldOption{
+		{Name: "Todo", Color: "GREEN"},
+		{Name: "In Progress", Color: "YELLOW"},
+		{Name: "Done", Color: "PURPLE"},
+	},
+}}
+
+// NewPlan compares project and its views with s. A nil project plans the
+// creation of a new project owned by owner. With prune, fields of the project
+// missing from s are deleted.
+func NewPlan(owner string, project *models.Project, views []models.ProjectView, s *Spec, prune bool) *Plan {
+	plan := &Plan{Owner: owner, Project: project, Spec: s}
+	current := project
+	if project == nil {
+		current = &models.Project{Title: s.Title, ShortDescription: s.Description, Fields: newProjectFields}
+		plan.Steps = append(plan.Steps, Step{
+			Action:  ActionCreate,
+			Summary: fmt.Sprintf("project %q", s.Title),
+		})
+	}
+	plan.diffSettings(current)
+	plan.diffFields(current, prune)
+	plan.diffViews(views)
+	return plan
+}
+
+func (plan *Plan) diffSettings(p *models.Project) {
+	s := plan.Spec
+	visibility := "private"
+	if s.Public {
+		visibility = "public"
+	}
+	for _, setting := range []struct{ name, want string }{
+		{models.SettingTitle, s.Title},
+		{models.SettingDescription, s.Description},
+		{models.SettingReadme, s.Readme},
+		{models.SettingVisibility, visibility},
+	} {
+		have := p.Setting(setting.name)
+		if have == setting.want {
+			continue
+		}
+		summary := fmt.Sprintf("%s: %q → %q", setting.name, have, setting.want)
+		switch setting.name {
+		case models.SettingReadme:
+			summary = fmt.Sprintf("readme (%s → %s)", lines(have), lines(setting.want))
+		case models.SettingVisibility:
+			summary = fmt.Sprintf("visibility: %s → %s", have, setting.want)
+		}
+		name, want := setting.name, setting.want
+		plan.Steps = append(plan.Steps, Step{
+			Action:  ActionUpdate,
+			Summary: summary,
+			apply: func(rec *journal.Recorder) error {
+				var edit ghc.ProjectEdit
+				if err := edit.Set(name, want); err != nil {
+					return err
+				}
+				if err := ghc.UpdateProject(p.ID, edit); err != nil {
+					return fmt.Errorf("updating the %s: %w", name, err)
+				}
+				rec.ProjectEdited(p, name, have, want)
+				return nil
+			},
+		})
+	}
+}
+
+func (plan *Plan) diffFields(p *models.Project, prune bool) {
+	for _, f := range plan.Spec.Fields {
+		want := f.Model()
+		i := slices.IndexFunc(p.Fields, func(have models.Field) bool { return strings.EqualFold(have.Name, f.Name) })
+		if i < 0 {
+			plan.Steps = append(plan.Steps, Step{
+				Action:  ActionCreate,
+				Summary: "field " + describeField(want),
+				apply: func(rec *journal.Recorder) error {
+					created, err := ghc.CreateField(p.ID, want)
+					if err != nil {
+						return fmt.Errorf("creating field %s: %w", want.Name, err)
+					}
+					rec.FieldCreated(p, *created)
+					return nil
+				},
+			})
+			continue
+		}
+
+		have := p.Fields[i]
+		if have.Type != want.Type {
+			plan.Steps = append(plan.Steps, Step{
+				Action: ActionManual,
+				Summary: fmt.Sprintf("field %s is %s in the project but %s in the file, recreate it by hand",
+					have.Name, typeName(have.Type), typeName(want.Type)),
+			})
+			continue
+		}
+
+		if want.Type == models.FieldTypeIteration && f.Iterations != nil &&
+			(have.IterationDuration != want.IterationDuration || have.IterationStartDay != want.IterationStartDay) {
+			plan.Steps = append(plan.Steps, Step{
+				Action: ActionManual,
+				Summary: fmt.Sprintf("field %s has %d day iterations starting on %s, change them to %d days starting on %s in the project settings",
+					have.Name, have.IterationDuration, have.IterationStartDay, want.IterationDuration, want.IterationStartDay),
+			})
+		}
+
+		updated, details := mergeField(have, want, prune)
+		if len(details) == 0 {
+			continue
+		}
+		before := have
+		plan.Steps = append(plan.Steps, Step{
+			Action:  ActionUpdate,
+			Summary: "field " + have.Name,
+			Details: details,
+			apply: func(rec *journal.Recorder) error {
+				after, err := ghc.UpdateField(updated)
+				if err != nil {
+					return fmt.Errorf("updating field %s: %w", before.Name, err)
+				}
+				rec.FieldUpdated(p, before, *after)
+				return nil
+			},
+		})
+	}
+
+	for _, have := range p.EditableFields() {
+		if have.Name == models.StatusField || slices.ContainsFunc(plan.Spec.Fields, func(f Field) bool { return strings.EqualFold(f.Name, have.Name) }) {
+			continue
+		}
+		if !prune {
+			plan.Kept = append(plan.Kept, have.Name)
+			continue
+		}
+		field := have
+		plan.Steps = append(plan.Steps, Step{
+			Action:  ActionDelete,
+			Summary: fmt.Sprintf("field %s and its values", field.Name),
+			apply: func(rec *journal.Recorder) error {
+				if err := ghc.DeleteField(field.ID); err != nil {
+					return fmt.Errorf("deleting field %s: %w", field.Name, err)
+				}
+				rec.FieldDeleted(p, field)
+				return nil
+			},
+		})
+	}
+}
+
+// mergeField returns have updated to match want, keeping the IDs of existing
+// options so items keep their values, and describes the differences
+func mergeField(have, want models.Field, prune bool) (models.Field, []string) {
+	var details []string
+	updated := have
+	if have.Name != want.Name {
+		updated.Name = want.Name
+		details = append(details, fmt.Sprintf("rename to %s", want.Name))
+	}
+	if have.Type != models.FieldTypeSingleSelect {
+		return updated, details
+	}
+
+	updated.Options = nil
+	// order lists the indexes in have.Options of the options kept, in their new order
+	var order []int
+	for _, o := range want.Options {
+		i := slices.IndexFunc(have.Options, func(h models.FieldOption) bool { return strings.EqualFold(h.Name, o.Name) })
+		if i < 0 {
+			updated.Options = append(updated.Options, o)
+			details = append(details, "+ option "+o.Name)
+			continue
+		}
+		h := have.Options[i]
+		merged := models.FieldOption{ID: h.ID, Name: o.Name, Color: o.Color, Description: o.Description}
+		if merged.Color == "" {
+			merged.Color = h.Color
+		}
+		var changes []string
+		if merged.Name != h.Name {
+			changes = append(changes, fmt.Sprintf("rename to %s", merged.Name))
+		}
+		if merged.Color != h.Color {
+			changes = append(changes, fmt.Sprintf("color %s → %s", strings.ToLower(h.Color), strings.ToLower(merged.Color)))
+		}
+		if merged.Description != h.Description {
+			changes = append(changes, fmt.Sprintf("description %q → %q", h.Description, merged.Description))
+		}
+		if len(changes) > 0 {
+			details = append(details, fmt.Sprintf("~ option %s: %s", h.Name, strings.Join(changes, ", ")))
+		}
+		updated.Options = append(updated.Options, merged)
+		order = append(order, i)
+	}
+	for i, h := range have.Options {
+		if slices.Contains(order, i) {
+			continue
+		}
+		if prune {
+			details = append(details, "- option "+h.Name)
+			continue
+		}
+		updated.Options = append(updated.Options, h)
+	}
+	if !slices.IsSorted(order) {
+		details = append(details, "~ reorder options")
+	}
+	return updated, details
+}
+
+func (plan *Plan) diffViews(views []models.ProjectView) {
+	for _, want := range plan.Spec.Views {
+		i := slices.IndexFunc(views, func(v models.ProjectView) bool { return strings.EqualFold(v.Name, want.Name) })
+		if i < 0 {
+			plan.Steps = append(plan.Steps, Step{
+				Action:  ActionManual,
+				Summary: fmt.Sprintf("view %q (%s) is missing, views cannot be created through the API so add it in the web UI", want.Name, want.Layout),
+			})
+			continue
+		}
+		have := FromProject(&models.Project{}, views[i:i+1]).Views[0]
+		var diffs []string
+		if have.Layout != want.Layout {
+			diffs = append(diffs, fmt.Sprintf("layout %s → %s", have.Layout, want.Layout))
+		}
+		if have.Filter != want.Filter {
+			diffs = append(diffs, fmt.Sprintf("filter %q → %q", have.Filter, want.Filter))
+		}
+		for _, list := range []struct {
+			name       string
+			have, want []string
+		}{
+			{"fields", have.Fields, want.Fields},
+			{"sort", have.SortBy, want.SortBy},
+			{"group", have.GroupBy, want.GroupBy},
+		} {
+			if len(list.want) > 0 && !slices.Equal(list.have, list.want) {
+				diffs = append(diffs, fmt.Sprintf("%s %s → %s", list.name, strings.Join(list.have, ", "), strings.Join(list.want, ", ")))
+			}
+		}
+		if len(diffs) > 0 {
+			plan.Steps = append(plan.Steps, Step{
+				Action:  ActionManual,
+				Summary: fmt.Sprintf("view %q differs, update it in the web UI", want.Name),
+				Details: diffs,
+			})
+		}
+	}
+}
+
+// Changes counts the steps that apply changes through the API
+func (plan *Plan) Changes() int {
+	n := 0
+	for _, s := range plan.Steps {
+		if s.Action != ActionManual {
+			n++
+		}
+	}
+	return n
+}
+
+// Print writes the plan in a diff-like form
+func (plan *Plan) Print(w io.Writer) {
+	if plan.Project == nil {
+		owner := plan.Owner
+		if owner == "" {
+			owner = "@me"
+		}
+		fmt.Fprintf(w, "Plan for a new project owned by %s:\n", owner)
+	} else {
+		fmt.Fprintf(w, "Plan for project %s/%d %q:\n", plan.Project.Owner, plan.Project.Number, plan.Project.Title)
+	}
+	if len(plan.Steps) == 0 {
+		fmt.Fprintln(w, "  no changes, the project matches the file")
+	}
+	for _, s := range plan.Steps {
+		fmt.Fprintf(w, "  %s %s\n", s.Action, s.Summary)
+		for _, d := range s.Details {
+			fmt.Fprintf(w, "      %s\n", d)
+		}
+	}
+	if len(plan.Kept) > 0 {
+		fmt.Fprintf(w, "\nKept fields missing from the file, pass --prune to delete them: %s\n", strings.Join(plan.Kept, ", "))
+	}
+	fmt.Fprintf(w, "\n%d to apply, %d to fix by hand\n", plan.Changes(), len(plan.Steps)-plan.Changes())
+}
+
+// Apply makes the changes of the plan, creating the project first when it
+// does not exist yet, and returns the resulting project
+func (plan *Plan) Apply(rec *journal.Recorder) (*models.Project, error) {
+	if plan.Project == nil {
+		created, err := ghc.CreateProject(plan.Owner, plan.Spec.Title, plan.Spec.Description)
+		if err != nil {
+			return nil, fmt.Errorf("creating the project: %w", err)
+		}
+		rec.ProjectCreated(plan.Owner, int(created.Number), created.Id)
+		project, err := ghc.GetProject(plan.Owner, int(created.Number))
+		if err != nil {
+			return nil, err
+		}
+		// Plan again against the fields GitHub actually created
+		next := NewPlan(plan.Owner, project, nil, plan.Spec, false)
+		if _, err := next.Apply(rec); err != nil {
+			return project, err
+		}
+		return project, nil
+	}
+
+	for _, s := range plan.Steps {
+		if s.apply == nil {
+			continue
+		}
+		if err := s.apply(rec); err != nil {
+			return plan.Project, err
+		}
+	}
+	return plan.Project, nil
+}
+
+func describeField(f models.Field) string {
+	desc := f.Name + " (" + typeName(f.Type)
+	if len(f.Options) > 0 {
+		names := make([]string, 0, len(f.Options))
+		for _, o := range f.Options {
+			names = append(names, o.Name)
+		}
+		desc += ": " + strings.Join(names, ", ")
+	}
+	if f.Type == models.FieldTypeIteration {
+		duration := f.IterationDuration
+		if duration <= 0 {
+			duration = 14
+		}
+		desc += fmt.Sprintf(": %d days from %s", duration, f.IterationStartDay)
+	}
+	return desc + ")"
+}
+
+func typeName(t models.FieldType) string {
+	return strings.ReplaceAll(strings.ToLower(string(t)), "_", " ")
+}
+
+func lines(s string) string {
+	if s == "" {
+		return "empty"
+	}
+	n := strings.Count(strings.TrimRight(s, "\n"), "\n") + 1
+	if n == 1 {
+		return "1 line"
+	}
+	return fmt.Sprintf("%d lines", n)
+}
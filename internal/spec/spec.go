@@ -0,0 +1,252 @@
+// Package spec describes a project as a YAML document that can be exported
+// from a project and applied to create or update one.
+package spec
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the declarative definition of a project
+type Spec struct {
+	// Owner and Number identify the project the spec was exported from
+	Owner       string  `yaml:"owner,omitempty"`
+	Number      int     `yaml:"number,omitempty"`
+	Title       string  `yaml:"title"`
+	Description string  `yaml:"description,omitempty"`
+	Readme      string  `yaml:"readme,omitempty"`
+	Public      bool    `yaml:"public,omitempty"`
+	Fields      []Field `yaml:"fields,omitempty"`
+	Views       []View  `yaml:"views,omitempty"`
+}
+
+// Field is a custom field of the project
+type Field struct {
+	Name string `yaml:"name"`
+	// Type is text, number, date, single_select or iteration
+	Type       string      `yaml:"type"`
+	Options    []Option    `yaml:"options,omitempty"`
+	Iterations *Iterations `yaml:"iterations,omitempty"`
+}
+
+// Option is an option of a single select field
+type Option struct {
+	Name string `yaml:"name"`
+	// Color is one of gray, blue, green, yellow, orange, red, pink or purple
+	Color       string `yaml:"color,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// Iterations configures the iterations of an iteration field
+type Iterations struct {
+	// Duration is the length of an iteration in days
+	Duration int `yaml:"duration"`
+	// StartDay is the weekday iterations start on, e.g. monday
+	StartDay string `yaml:"start_day,omitempty"`
+}
+
+// View is a view of the project. Views cannot be created through the API, so
+// they are only compared when applying.
+type View struct {
+	Name string `yaml:"name"`
+	// Layout is board, table or roadmap
+	Layout string   `yaml:"layout"`
+	Filter string   `yaml:"filter,omitempty"`
+	Fields []string `yaml:"fields,omitempty"`
+	// SortBy lists fields to sort by, with :desc appended for descending order
+	SortBy  []string `yaml:"sort_by,omitempty"`
+	GroupBy []string `yaml:"group_by,omitempty"`
+}
+
+// Colors lists the colors of single select options
+var Colors = []string{"gray", "blue", "green", "yellow", "orange", "red", "pink", "purple"}
+
+// fieldTypes maps the types used in specs to the API field types
+var fieldTypes = map[string]models.FieldType{
+	"text":          models.FieldTypeText,
+	"number":        models.FieldTypeNumber,
+	"date":          models.FieldTypeDate,
+	"single_select": models.FieldTypeSingleSelect,
+	"iteration":     models.FieldTypeIteration,
+}
+
+// FromProject builds the spec of an existing project and its views
+func FromProject(p *models.Project, views []models.ProjectView) *Spec {
+	s := &Spec{
+		Owner:       p.Owner,
+		Number:      p.Number,
+		Title:       p.Title,
+		Description: p.ShortDescription,
+		Readme:      p.Readme,
+		Public:      p.Public,
+	}
+	for _, f := range p.EditableFields() {
+		field := Field{Name: f.Name, Type: strings.ToLower(string(f.Type))}
+		for _, o := range f.Options {
+			field.Options = append(field.Options, Option{
+				Name:        o.Name,
+				Color:       strings.ToLower(o.Color),
+				Description: o.Description,
+			})
+		}
+		if f.Type == models.FieldTypeIteration {
+			field.Iterations = &Iterations{
+				Duration: f.IterationDuration,
+				StartDay: strings.ToLower(f.IterationStartDay.String()),
+			}
+		}
+		s.Fields = append(s.Fields, field)
+	}
+	for _, v := range views {
+		view := View{
+			Name:    v.Name,
+			Layout:  strings.ToLower(strings.TrimSuffix(v.Layout, "_LAYOUT")),
+			Filter:  v.Filter,
+			Fields:  v.Fields,
+			GroupBy: v.GroupBy,
+		}
+		for _, sort := range v.SortBy {
+			by := sort.Field
+			if sort.Direction == "DESC" {
+				by += ":desc"
+			}
+			view.SortBy = append(view.SortBy, by)
+		}
+		s.Views = append(s.Views, view)
+	}
+	return s
+}
+
+// Load reads and validates a spec file; "-" reads standard input
+func Load(path string) (*Spec, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a spec
+func Parse(data []byte) (*Spec, error) {
+	var s Spec
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks the spec for missing names, unknown types and duplicates
+func (s *Spec) Validate() error {
+	if strings.TrimSpace(s.Title) == "" {
+		return fmt.Errorf("the project needs a title")
+	}
+	seen := map[string]bool{}
+	for _, f := range s.Fields {
+		if strings.TrimSpace(f.Name) == "" {
+			return fmt.Errorf("a field has no name")
+		}
+		if seen[strings.ToLower(f.Name)] {
+			return fmt.Errorf("field %q is defined twice", f.Name)
+		}
+		seen[strings.ToLower(f.Name)] = true
+
+		t, err := ParseFieldType(f.Type)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", f.Name, err)
+		}
+		if t == models.FieldTypeSingleSelect && len(f.Options) == 0 {
+			return fmt.Errorf("single select field %q needs options", f.Name)
+		}
+		if t != models.FieldTypeSingleSelect && len(f.Options) > 0 {
+			return fmt.Errorf("field %q of type %s cannot have options", f.Name, f.Type)
+		}
+		options := map[string]bool{}
+		for _, o := range f.Options {
+			if options[strings.ToLower(o.Name)] {
+				return fmt.Errorf("field %q has option %q twice", f.Name, o.Name)
+			}
+			options[strings.ToLower(o.Name)] = true
+			if o.Color != "" && !slices.Contains(Colors, strings.ToLower(o.Color)) {
+				return fmt.Errorf("option %q of %q has unknown color %q, expected one of %s", o.Name, f.Name, o.Color, strings.Join(Colors, ", "))
+			}
+		}
+		if f.Iterations != nil {
+			if t != models.FieldTypeIteration {
+				return fmt.Errorf("field %q of type %s cannot have iterations", f.Name, f.Type)
+			}
+			if f.Iterations.Duration < 1 {
+				return fmt.Errorf("field %q: iterations need a duration of at least one day", f.Name)
+			}
+			if _, err := ParseWeekday(f.Iterations.StartDay); err != nil {
+				return fmt.Errorf("field %q: %w", f.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Write encodes the spec as YAML
+func (s *Spec) Write(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Model returns the field definition to create for f
+func (f Field) Model() models.Field {
+	t, _ := ParseFieldType(f.Type)
+	field := models.Field{Name: f.Name, Type: t}
+	for _, o := range f.Options {
+		field.Options = append(field.Options, models.FieldOption{
+			Name:        o.Name,
+			Color:       strings.ToUpper(o.Color),
+			Description: o.Description,
+		})
+	}
+	if f.Iterations != nil {
+		field.IterationDuration = f.Iterations.Duration
+		field.IterationStartDay, _ = ParseWeekday(f.Iterations.StartDay)
+	}
+	return field
+}
+
+// ParseFieldType parses a field type as written in specs, e.g. single_select
+func ParseFieldType(s string) (models.FieldType, error) {
+	t, ok := fieldTypes[strings.ReplaceAll(strings.ToLower(s), "-", "_")]
+	if !ok {
+		return "", fmt.Errorf("unknown field type %q, expected text, number, date, single_select or iteration", s)
+	}
+	return t, nil
+}
+
+// ParseWeekday parses a weekday name such as monday; empty means Monday
+func ParseWeekday(s string) (time.Weekday, error) {
+	if s == "" {
+		return time.Monday, nil
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) || strings.EqualFold(d.String()[:3], s) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
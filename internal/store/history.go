@@ -0,0 +1,182 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// Transition is a recorded change of a field value on an item
+type Transition struct {
+	ItemID string
+	Field  string
+	From   string
+	To     string
+	At     time.Time
+	// Initial marks the value an item had when it was first synced, which has no From
+	Initial bool
+}
+
+// recordTransitions compares items against the cached copy of the project and
+// stores a transition for every field value that differs. The time of a change
+// is taken from the item's updatedAt when it moved since the previous sync,
+// which is exact when the field edit was the item's latest update.
+func recordTransitions(tx *sql.Tx, projectID string, items []models.Item) error {
+	type previous struct {
+		updatedAt time.Time
+		values    map[string]models.FieldValue
+	}
+	rows, err := tx.Query(`SELECT id, updated_at, CAST(field_values AS VARCHAR) FROM items WHERE project_id = ?`, projectID)
+	if err != nil {
+		return err
+	}
+	known := map[string]previous{}
+	for rows.Next() {
+		var id, raw string
+		var prev previous
+		if err := rows.Scan(&id, &prev.updatedAt, &raw); err != nil {
+			rows.Close()
+			return err
+		}
+		if err := json.Unmarshal([]byte(raw), &prev.values); err != nil {
+			rows.Close()
+			return err
+		}
+		known[id] = prev
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO transitions VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, item := range items {
+		prev, seen := known[item.ID]
+		at := now
+		if !item.UpdatedAt.IsZero() && (!seen || item.UpdatedAt.After(prev.updatedAt)) {
+			at = item.UpdatedAt
+		}
+
+		fields := map[string]bool{}
+		for name := range item.Values {
+			fields[name] = true
+		}
+		for name := range prev.values {
+			fields[name] = true
+		}
+		for name := range fields {
+			to := item.Values[name].Text
+			if !seen {
+				if _, err := stmt.Exec(projectID, item.ID, name, nil, to, at); err != nil {
+					return err
+				}
+				continue
+			}
+			if from := prev.values[name].Text; from != to {
+				if _, err := stmt.Exec(projectID, item.ID, name, from, to, at); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Transitions returns the recorded changes of a field on the items of a project, oldest first
+func (s *Store) Transitions(projectID, field string) ([]Transition, error) {
+	rows, err := s.db.Query(`SELECT item_id, field, from_value, to_value, at FROM transitions
+		WHERE project_id = ? AND lower(field) = lower(?) ORDER BY at, item_id`, projectID, field)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []Transition
+	for rows.Next() {
+		var t Transition
+		var from sql.NullString
+		if err := rows.Scan(&t.ItemID, &t.Field, &from, &t.To, &t.At); err != nil {
+			return nil, err
+		}
+		t.From, t.Initial = from.String, !from.Valid
+		transitions = append(transitions, t)
+	}
+	return transitions, rows.Err()
+}
+
+// CycleTime is the time an item took from entering a start status to a done status
+type CycleTime struct {
+	ItemID   string
+	Started  time.Time
+	Finished time.Time
+}
+
+// Duration returns the elapsed time
+func (c CycleTime) Duration() time.Duration {
+	return c.Finished.Sub(c.Started)
+}
+
+// CycleTimes pairs the first transition of field into one of start with the
+// first later transition into one of done, for items finished since the given
+// time. Values seen on the first sync are left out as starts, since when the
+// item entered them is unknown.
+func (s *Store) CycleTimes(projectID, field string, start, done []string, since time.Time) ([]CycleTime, error) {
+	args := []any{projectID, field}
+	args = append(args, lowerAll(start)...)
+	args = append(args, projectID, field)
+	args = append(args, lowerAll(done)...)
+	args = append(args, since)
+
+	rows, err := s.db.Query(`
+		WITH starts AS (
+			SELECT item_id, min(at) AS started FROM transitions
+			WHERE project_id = ? AND lower(field) = lower(?) AND lower(to_value) IN (`+placeholders(len(start))+`)
+				AND from_value IS NOT NULL
+			GROUP BY item_id
+		)
+		SELECT s.item_id, s.started, min(t.at) AS finished
+		FROM starts s JOIN transitions t ON t.item_id = s.item_id
+		WHERE t.project_id = ? AND lower(t.field) = lower(?) AND lower(t.to_value) IN (`+placeholders(len(done))+`)
+			AND t.at >= s.started
+		GROUP BY s.item_id, s.started
+		HAVING min(t.at) >= ?
+		ORDER BY finished`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var times []CycleTime
+	for rows.Next() {
+		var c CycleTime
+		if err := rows.Scan(&c.ItemID, &c.Started, &c.Finished); err != nil {
+			return nil, err
+		}
+		times = append(times, c)
+	}
+	return times, rows.Err()
+}
+
+func placeholders(n int) string {
+	if n == 0 {
+		return "NULL"
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func lowerAll(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = strings.ToLower(v)
+	}
+	return out
+}
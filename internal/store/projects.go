@@ -0,0 +1,162 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+const itemColumns = `id, type, content_id, number, title, body, url, repository, state,
+	CAST(assignees AS VARCHAR), CAST(labels AS VARCHAR), milestone, CAST(linked_prs AS VARCHAR),
+	archived, updated_at, CAST(field_values AS VARCHAR)`
+
+// SaveProject replaces the cached copy of project and its items, recording the
+// field values that changed since the previous sync as transitions and the
+// current values as the snapshot of the day
+func (s *Store) SaveProject(p *models.Project, items []models.Item) error {
+	fields, err := json.Marshal(p.Fields)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM projects WHERE id = ?`, p.ID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO projects VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		p.ID, p.Owner, p.Number, p.Title, p.ShortDescription, p.URL, p.Closed, string(fields), time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := recordTransitions(tx, p.ID, items); err != nil {
+		return err
+	}
+	if err := recordSnapshot(tx, p.ID, time.Now(), items); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM items WHERE project_id = ?`, p.ID); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`INSERT INTO items VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, item := range items {
+		_, err := stmt.Exec(item.ID, p.ID, item.Type, item.ContentID, item.Number, item.Title, item.Body,
+			item.URL, item.Repository, item.State, jsonList(item.Assignees), jsonList(item.Labels),
+			item.Milestone, jsonList(item.LinkedPRs), item.Archived, item.UpdatedAt, jsonValues(item.Values))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Project returns the cached project with the given owner and number
+func (s *Store) Project(owner string, number int) (*models.Project, error) {
+	row := s.db.QueryRow(`SELECT id, owner, number, title, short_description, url, closed, CAST(fields AS VARCHAR)
+		FROM projects WHERE lower(owner) = lower(?) AND number = ?`, owner, number)
+	p, err := scanProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotCached
+	}
+	return p, err
+}
+
+// Projects returns every cached project ordered by owner and number
+func (s *Store) Projects() ([]models.Project, error) {
+	rows, err := s.db.Query(`SELECT id, owner, number, title, short_description, url, closed, CAST(fields AS VARCHAR)
+		FROM projects ORDER BY owner, number`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var projects []models.Project
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *p)
+	}
+	return projects, rows.Err()
+}
+
+// Items returns the cached items of a project. where is an optional SQL
+// condition over the items table, such as the one built by filter.Query.SQL,
+// with args bound to its placeholders.
+func (s *Store) Items(projectID, where string, args ...any) ([]models.Item, error) {
+	query := `SELECT ` + itemColumns + ` FROM items WHERE project_id = ?`
+	if strings.TrimSpace(where) != "" {
+		query += ` AND (` + where + `)`
+	}
+	rows, err := s.db.Query(query, append([]any{projectID}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.Item
+	for rows.Next() {
+		var item models.Item
+		var assignees, labels, linked, values string
+		err := rows.Scan(&item.ID, &item.Type, &item.ContentID, &item.Number, &item.Title, &item.Body,
+			&item.URL, &item.Repository, &item.State, &assignees, &labels, &item.Milestone, &linked,
+			&item.Archived, &item.UpdatedAt, &values)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range []struct {
+			raw string
+			dst any
+		}{{assignees, &item.Assignees}, {labels, &item.Labels}, {linked, &item.LinkedPRs}, {values, &item.Values}} {
+			if err := json.Unmarshal([]byte(v.raw), v.dst); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func scanProject(row interface{ Scan(...any) error }) (*models.Project, error) {
+	var p models.Project
+	var fields string
+	err := row.Scan(&p.ID, &p.Owner, &p.Number, &p.Title, &p.ShortDescription, &p.URL, &p.Closed, &fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(fields), &p.Fields); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// jsonList encodes a list so that a nil slice is stored as an empty array
+func jsonList(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+	b, _ := json.Marshal(values)
+	return string(b)
+}
+
+// jsonValues encodes field values keyed by field name
+func jsonValues(values map[string]models.FieldValue) string {
+	if values == nil {
+		values = map[string]models.FieldValue{}
+	}
+	b, _ := json.Marshal(values)
+	return string(b)
+}
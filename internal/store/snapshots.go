@@ -0,0 +1,69 @@
+package store
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// DayCount is the number of items having a field value on a day
+type DayCount struct {
+	Day   time.Time
+	Value string
+	Count int
+}
+
+// recordSnapshot replaces the snapshot of the day with the values of the unarchived items
+func recordSnapshot(tx *sql.Tx, projectID string, now time.Time, items []models.Item) error {
+	day := now.Format(time.DateOnly)
+	if _, err := tx.Exec(`DELETE FROM snapshots WHERE project_id = ? AND day = CAST(? AS DATE)`, projectID, day); err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`INSERT INTO snapshots VALUES (?, CAST(? AS DATE), ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, item := range items {
+		if item.Archived {
+			continue
+		}
+		if _, err := stmt.Exec(projectID, day, item.ID, jsonValues(item.Values)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DailyCounts counts the snapshotted items per day and value of a field for
+// the days since the given one; items without a value are counted under ""
+func (s *Store) DailyCounts(projectID, field string, since time.Time) ([]DayCount, error) {
+	rows, err := s.db.Query(`
+		SELECT day, coalesce(json_extract_string(field_values, ?), '') AS value, count(*)
+		FROM snapshots
+		WHERE project_id = ? AND day >= CAST(? AS DATE)
+		GROUP BY day, value
+		ORDER BY day, value`, FieldPath(field), projectID, since.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []DayCount
+	for rows.Next() {
+		var c DayCount
+		if err := rows.Scan(&c.Day, &c.Value, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// FieldPath returns the JSON path of a field's display value in the
+// field_values column of the items tables
+func FieldPath(name string) string {
+	return `$."` + strings.ReplaceAll(name, `"`, `\"`) + `".text`
+}
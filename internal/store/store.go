@@ -0,0 +1,198 @@
+// Package store keeps a local DuckDB cache of projects and their items so
+// that queries, reports and completions do not need the network.
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/marcboeker/go-duckdb"
+)
+
+const fileName = "cache.duckdb"
+
+// MetaViewer is the meta key holding the login of the user who synced the cache
+const MetaViewer = "viewer"
+
+// MetaOrgs is the meta key holding the comma separated organizations of the
+// viewer, saved for shell completions
+const MetaOrgs = "orgs"
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS meta (
+		key VARCHAR NOT NULL,
+		value VARCHAR
+	)`,
+	`CREATE TABLE IF NOT EXISTS projects (
+		id VARCHAR NOT NULL,
+		owner VARCHAR NOT NULL,
+		number INTEGER NOT NULL,
+		title VARCHAR,
+		short_description VARCHAR,
+		url VARCHAR,
+		closed BOOLEAN,
+		fields JSON,
+		synced_at TIMESTAMPTZ
+	)`,
+	`CREATE TABLE IF NOT EXISTS items (
+		id VARCHAR NOT NULL,
+		project_id VARCHAR NOT NULL,
+		type VARCHAR,
+		content_id VARCHAR,
+		number INTEGER,
+		title VARCHAR,
+		body VARCHAR,
+		url VARCHAR,
+		repository VARCHAR,
+		state VARCHAR,
+		assignees JSON,
+		labels JSON,
+		milestone VARCHAR,
+		linked_prs JSON,
+		archived BOOLEAN,
+		updated_at TIMESTAMPTZ,
+		field_values JSON
+	)`,
+	// transitions records every change of a field value seen between syncs;
+	// from_value is NULL for the value an item had when it was first synced
+	`CREATE TABLE IF NOT EXISTS transitions (
+		project_id VARCHAR NOT NULL,
+		item_id VARCHAR NOT NULL,
+		field VARCHAR NOT NULL,
+		from_value VARCHAR,
+		to_value VARCHAR,
+		at TIMESTAMPTZ NOT NULL
+	)`,
+	// snapshots keeps the field values of the unarchived items once per day
+	`CREATE TABLE IF NOT EXISTS snapshots (
+		project_id VARCHAR NOT NULL,
+		day DATE NOT NULL,
+		item_id VARCHAR NOT NULL,
+		field_values JSON
+	)`,
+}
+
+// ErrNotCached is returned when the requested data has not been synced
+var ErrNotCached = errors.New("not in the local cache, run `gh pm project sync` first")
+
+// Store is the local cache database
+type Store struct {
+	db *sql.DB
+}
+
+// Path returns the location of the cache database
+func Path() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-pm", fileName), nil
+}
+
+// Exists reports whether the cache database has been created
+func Exists() bool {
+	path, err := Path()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Open opens the cache database, creating it and its tables if needed
+func Open() (*Store, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("duckdb", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening cache %s: %w", path, err)
+	}
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("migrating cache %s: %w", path, err)
+		}
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Meta returns a value saved with SetMeta, or "" when unset
+func (s *Store) Meta(key string) (string, error) {
+	var value string
+	err := s.db.QueryRow(`SELECT value FROM meta WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return value, err
+}
+
+// SetMeta saves a value such as the viewer login under key
+func (s *Store) SetMeta(key, value string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(`DELETE FROM meta WHERE key = ?`, key); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO meta VALUES (?, ?)`, key, value); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Stats summarizes the content of the cache
+type Stats struct {
+	Projects int
+	Items    int
+	// LastSync is when a project was last synced, zero before the first sync
+	LastSync time.Time
+}
+
+// Stats counts the cached projects and items
+func (s *Store) Stats() (Stats, error) {
+	var stats Stats
+	var lastSync sql.NullTime
+	err := s.db.QueryRow(`SELECT count(*), max(synced_at) FROM projects`).Scan(&stats.Projects, &lastSync)
+	if err != nil {
+		return stats, err
+	}
+	stats.LastSync = lastSync.Time
+	err = s.db.QueryRow(`SELECT count(*) FROM items`).Scan(&stats.Items)
+	return stats, err
+}
+
+// Size returns the size in bytes of the cache database and its write-ahead log
+func Size() (int64, error) {
+	path, err := Path()
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, p := range []string{path, path + ".wal"} {
+		info, err := os.Stat(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		size += info.Size()
+	}
+	return size, nil
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	datePickerTitleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	datePickerWeekdayStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	datePickerSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#4F5D75"))
+	datePickerTodayStyle    = lipgloss.NewStyle().Underline(true)
+)
+
+// DatePicker is a month calendar for choosing a single day
+type DatePicker struct {
+	title    string
+	selected time.Time
+}
+
+// NewDatePicker creates a date picker starting at value, or today when value is zero
+func NewDatePicker(title string, value time.Time) DatePicker {
+	if value.IsZero() {
+		value = time.Now()
+	}
+	return DatePicker{
+		title:    title,
+		selected: time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.Local),
+	}
+}
+
+// Value returns the selected day
+func (d DatePicker) Value() time.Time {
+	return d.selected
+}
+
+// Update moves the selection: arrows by day and week, pgup/pgdown by month, t for today
+func (d DatePicker) Update(msg tea.Msg) (DatePicker, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return d, nil
+	}
+	switch key.String() {
+	case "left", "h":
+		d.selected = d.selected.AddDate(0, 0, -1)
+	case "right", "l":
+		d.selected = d.selected.AddDate(0, 0, 1)
+	case "up", "k":
+		d.selected = d.selected.AddDate(0, 0, -7)
+	case "down", "j":
+		d.selected = d.selected.AddDate(0, 0, 7)
+	case "pgup", "[":
+		d.selected = d.selected.AddDate(0, -1, 0)
+	case "pgdown", "]":
+		d.selected = d.selected.AddDate(0, 1, 0)
+	case "t":
+		d = NewDatePicker(d.title, time.Now())
+	}
+	return d, nil
+}
+
+// View renders the month containing the selected day
+func (d DatePicker) View() string {
+	var sb strings.Builder
+	sb.WriteString(datePickerTitleStyle.Render(d.title))
+	sb.WriteString("\n")
+	sb.WriteString(d.selected.Format("January 2006"))
+	sb.WriteString("\n")
+	sb.WriteString(datePickerWeekdayStyle.Render("Su Mo Tu We Th Fr Sa"))
+	sb.WriteString("\n")
+
+	first := time.Date(d.selected.Year(), d.selected.Month(), 1, 0, 0, 0, 0, time.Local)
+	today := time.Now().Format(time.DateOnly)
+	sb.WriteString(strings.Repeat("   ", int(first.Weekday())))
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", day.Day())
+		switch {
+		case day.Equal(d.selected):
+			cell = datePickerSelectedStyle.Render(cell)
+		case day.Format(time.DateOnly) == today:
+			cell = datePickerTodayStyle.Render(cell)
+		}
+		sb.WriteString(cell)
+		if day.Weekday() == time.Saturday {
+			sb.WriteString("\n")
+		} else {
+			sb.WriteString(" ")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,22 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// EditorCommand returns the command that opens path in the editor set by
+// GH_EDITOR, VISUAL or EDITOR, falling back to vi. The editor may include
+// arguments, e.g. "code --wait". The caller connects the standard streams.
+func EditorCommand(path string) *exec.Cmd {
+	editor := "vi"
+	for _, env := range []string{"GH_EDITOR", "VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			editor = v
+			break
+		}
+	}
+	args := strings.Fields(editor)
+	return exec.Command(args[0], append(args[1:], path)...)
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/prnk28/gh-pm/internal/ghc"
 )
 
 var (
@@ -12,7 +13,11 @@ var (
 		Width(80)
 )
 
-// Footer returns a styled footer with help text
+// Footer returns a styled footer with help text, followed by the remaining
+// API budget once a response reported it
 func Footer(helpText string) string {
+	if b, ok := ghc.RateLimit(); ok {
+		helpText += " • " + b.String()
+	}
 	return footerStyle.Render(helpText)
 }
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh/pkg/tableprinter"
+	"github.com/cli/go-gh/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+// NoTUIFlag is the persistent root flag that disables Bubble Tea programs and huh forms
+const NoTUIFlag = "no-tui"
+
+// IsInteractive reports whether the command may start a Bubble Tea program or a huh form.
+// It is false when --no-tui is set or when stdin or stdout is not a terminal.
+func IsInteractive(cmd *cobra.Command) bool {
+	if noTUI, err := cmd.Flags().GetBool(NoTUIFlag); err == nil && noTUI {
+		return false
+	}
+	return term.FromEnv().IsTerminalOutput() && term.IsTerminal(os.Stdin)
+}
+
+// RequireFlags returns the error used when a form cannot be shown, listing the flags to pass instead
+func RequireFlags(form string, flags ...string) error {
+	return fmt.Errorf("cannot show the %s form in non-interactive mode; pass %s instead", form, strings.Join(flags, ", "))
+}
+
+// NewTablePrinter returns a table printer that fits the terminal width. When stdout
+// is not a terminal it prints tab-separated values and skips the header row.
+func NewTablePrinter(w io.Writer, headers ...string) tableprinter.TablePrinter {
+	t := term.FromEnv()
+	tp := tableprinter.New(w, t.IsTerminalOutput(), TerminalWidth())
+	if t.IsTerminalOutput() && len(headers) > 0 {
+		for _, h := range headers {
+			tp.AddField(h)
+		}
+		tp.EndRow()
+	}
+	return tp
+}
+
+// TerminalWidth returns the width of the terminal, or 80 when it cannot be determined
+func TerminalWidth() int {
+	width, _, err := term.FromEnv().Size()
+	if err != nil || width <= 0 {
+		return 80
+	}
+	return width
+}
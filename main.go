@@ -1,17 +1,24 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/prnk28/gh-pm/x/deployment"
+	"github.com/prnk28/gh-pm/x/doctor"
 	"github.com/prnk28/gh-pm/x/release"
 	"github.com/prnk28/gh-pm/x/milestone"
+	"github.com/prnk28/gh-pm/x/mine"
 	"github.com/prnk28/gh-pm/x/project"
 	"github.com/prnk28/gh-pm/x/pulls"
 	"github.com/prnk28/gh-pm/x/issue"
+	"github.com/prnk28/gh-pm/x/report"
+	"github.com/prnk28/gh-pm/x/sprint"
+	"github.com/prnk28/gh-pm/x/undo"
+	"github.com/prnk28/gh-pm/x/view"
 
 	"github.com/prnk28/gh-pm/app"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/complete"
 	"github.com/spf13/cobra"
 )
 
@@ -22,13 +29,20 @@ var commands = []*cobra.Command{
 	project.Command(),
 	pulls.Command(),
 	issue.Command(),
+	view.Command(),
+	sprint.Command(),
+	report.Command(),
+	undo.Command(),
+	mine.Command(),
+	doctor.Command(),
 }
 
 func main() {
 	rootCmd := app.RootCmd()
 	rootCmd.AddCommand(commands...)
+	complete.RegisterFlags(rootCmd)
+	// Cobra prints the errors of flags and arguments itself
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(clierr.ExitValidation)
 	}
 }
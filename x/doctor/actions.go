@@ -0,0 +1,352 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/config"
+	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/store"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// apiTimeout bounds the request checking that the API answers
+const apiTimeout = 15 * time.Second
+
+// Statuses of a check; only failed checks make doctor exit with an error
+const (
+	statusPass = "pass"
+	statusWarn = "warn"
+	statusFail = "fail"
+	statusSkip = "skip"
+)
+
+var (
+	passStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	warnStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	failStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	fixStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+// check is the outcome of one diagnostic
+type check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	// Fix tells how to make a failed check pass
+	Fix string `json:"fix,omitempty"`
+}
+
+// report is the --json output, meant to be attached to bug reports
+type report struct {
+	OS     string  `json:"os"`
+	Arch   string  `json:"arch"`
+	Go     string  `json:"go"`
+	Checks []check `json:"checks"`
+	Failed int     `json:"failed"`
+}
+
+// env carries what the checks learn for the checks after them
+type env struct {
+	gh   bool
+	auth *ctx.Auth
+}
+
+func doctorAction(cmd *cobra.Command, args []string) {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	fix, _ := cmd.Flags().GetBool("fix")
+
+	checks := runChecks()
+	if fix {
+		if fixChecks(cmd, checks) {
+			checks = runChecks()
+		}
+	}
+	failed := 0
+	for _, c := range checks {
+		if c.Status == statusFail {
+			failed++
+		}
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(report{
+			OS:     runtime.GOOS,
+			Arch:   runtime.GOARCH,
+			Go:     runtime.Version(),
+			Checks: checks,
+			Failed: failed,
+		})
+		if err != nil {
+			clierr.Exit(err)
+		}
+	} else {
+		printChecks(checks)
+		if failed > 0 {
+			fmt.Fprintf(os.Stderr, "\n%d of %d checks failed\n", failed, len(checks))
+		}
+	}
+	if failed > 0 {
+		os.Exit(clierr.ExitError)
+	}
+}
+
+func printChecks(checks []check) {
+	for _, c := range checks {
+		var mark string
+		switch c.Status {
+		case statusPass:
+			mark = passStyle.Render("✓")
+		case statusWarn:
+			mark = warnStyle.Render("!")
+		case statusFail:
+			mark = failStyle.Render("✗")
+		default:
+			mark = fixStyle.Render("-")
+		}
+		fmt.Printf("%s %s: %s\n", mark, c.Name, c.Detail)
+		if c.Status != statusPass && c.Fix != "" {
+			fmt.Println(fixStyle.Render("  Fix: " + c.Fix))
+		}
+	}
+}
+
+// runChecks runs the diagnostics in order; checks that depend on a failed one
+// are skipped
+func runChecks() []check {
+	var e env
+	return []check{
+		checkGH(&e),
+		checkLogin(&e),
+		checkScopes(&e),
+		checkGit(),
+		checkExtensions(&e),
+		checkConfig(),
+		checkCache(),
+		checkAPI(&e),
+	}
+}
+
+func checkGH(e *env) check {
+	c := check{Name: "gh", Status: statusFail}
+	version, err := ctx.GHVersion()
+	if err != nil {
+		c.Detail = err.Error()
+		c.Fix = "install gh from https://cli.github.com"
+		return c
+	}
+	c.Detail = "version " + version
+	if ctx.CompareVersions(version, ctx.MinGHVersion) < 0 {
+		c.Detail += ", " + ctx.MinGHVersion + " or newer is needed"
+		c.Fix = "upgrade gh, see https://github.com/cli/cli#installation"
+		return c
+	}
+	e.gh = true
+	c.Status = statusPass
+	return c
+}
+
+func checkLogin(e *env) check {
+	c := check{Name: "Login"}
+	auth, err := ctx.GetAuth()
+	if err != nil {
+		c.Status, c.Detail, c.Fix = statusFail, err.Error(), clierr.Classify(err).Hint
+		return c
+	}
+	e.auth = &auth
+	c.Status, c.Detail = statusPass, "logged in as "+auth.Login
+	return c
+}
+
+func checkScopes(e *env) check {
+	c := check{Name: "Token scopes"}
+	switch {
+	case e.auth == nil:
+		c.Status, c.Detail = statusSkip, "not logged in"
+	case e.auth.Scopes == nil:
+		c.Status = statusWarn
+		c.Detail = "not reported by the token, make sure it can read and write projects"
+	case e.auth.HasScope(ctx.ScopeProject):
+		c.Status, c.Detail = statusPass, strings.Join(e.auth.Scopes, ", ")
+	case e.auth.HasScope(ctx.ScopeReadProject):
+		c.Status, c.Detail, c.Fix = statusWarn, "read:project only, projects cannot be changed", clierr.ScopeHint
+	default:
+		c.Status, c.Detail, c.Fix = statusFail, "missing project", clierr.ScopeHint
+	}
+	return c
+}
+
+// checkGit looks for the git executable used to find the current branch
+func checkGit() check {
+	c := check{Name: "git"}
+	out, err := exec.Command("git", "--version").Output()
+	if err != nil {
+		c.Status, c.Detail = statusWarn, "not found, the current branch cannot be detected"
+		c.Fix = "install git from https://git-scm.com"
+		return c
+	}
+	c.Status, c.Detail = statusPass, strings.TrimPrefix(strings.TrimSpace(string(out)), "git ")
+	if branch, err := ctx.CurrentBranch(); err == nil && branch != "" {
+		c.Detail += ", on branch " + branch
+	}
+	return c
+}
+
+func checkExtensions(e *env) check {
+	c := check{Name: "Extensions"}
+	if !e.gh {
+		c.Status, c.Detail = statusSkip, "gh is not available"
+		return c
+	}
+	missing, err := ctx.MissingExtensions()
+	switch {
+	case err != nil:
+		c.Status, c.Detail = statusFail, err.Error()
+	case len(missing) > 0:
+		c.Status, c.Detail = statusFail, "missing "+strings.Join(missing, ", ")
+		c.Fix = "run `gh pm doctor --fix`, or `gh extension install " + strings.Join(missing, " ") + "`"
+	default:
+		c.Status, c.Detail = statusPass, strings.Join(ctx.ExtensionDeps(), ", ")
+	}
+	return c
+}
+
+func checkConfig() check {
+	c := check{Name: "Config"}
+	path, err := config.Path()
+	if err != nil {
+		c.Status, c.Detail = statusFail, err.Error()
+		return c
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		c.Status, c.Detail = statusPass, "no config file, using the defaults"
+		return c
+	}
+	cfg, err := config.Load()
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		c.Status = statusFail
+		c.Detail = strings.ReplaceAll(err.Error(), "\n", "; ")
+		c.Fix = "edit " + path
+		return c
+	}
+	c.Status, c.Detail = statusPass, path
+	return c
+}
+
+func checkCache() check {
+	c := check{Name: "Cache"}
+	path, err := store.Path()
+	if err != nil {
+		c.Status, c.Detail = statusFail, err.Error()
+		return c
+	}
+	if !store.Exists() {
+		c.Status, c.Detail = statusWarn, "not created, --cached and reports have nothing to read"
+		c.Fix = "run `gh pm project sync`"
+		return c
+	}
+	rebuild := fmt.Sprintf("delete %s and run `gh pm project sync`", path)
+	s, err := store.Open()
+	if err != nil {
+		c.Status, c.Detail, c.Fix = statusFail, err.Error(), rebuild
+		return c
+	}
+	defer s.Close()
+	stats, err := s.Stats()
+	if err != nil {
+		c.Status, c.Detail, c.Fix = statusFail, err.Error(), rebuild
+		return c
+	}
+	size, err := store.Size()
+	if err != nil {
+		c.Status, c.Detail = statusFail, err.Error()
+		return c
+	}
+	c.Status = statusPass
+	c.Detail = fmt.Sprintf("%d projects, %d items, %s", stats.Projects, stats.Items, megabytes(size))
+	if !stats.LastSync.IsZero() {
+		c.Detail += ", synced " + text.RelativeTimeAgo(time.Now(), stats.LastSync)
+	}
+	return c
+}
+
+func checkAPI(e *env) check {
+	c := check{Name: "GitHub API"}
+	if e.auth == nil {
+		c.Status, c.Detail = statusSkip, "not logged in"
+		return c
+	}
+	reqCtx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+	start := time.Now()
+	if _, err := ghc.Ping(reqCtx); err != nil {
+		c.Status, c.Detail, c.Fix = statusFail, err.Error(), clierr.Classify(err).Hint
+		return c
+	}
+	c.Status = statusPass
+	c.Detail = "answered in " + time.Since(start).Round(time.Millisecond).String()
+	if b, ok := ghc.RateLimit(); ok {
+		c.Detail += ", " + b.String()
+	}
+	return c
+}
+
+// fixChecks installs missing extensions and adds missing token scopes, asking
+// for the scopes only in a terminal. It reports whether anything was fixed.
+func fixChecks(cmd *cobra.Command, checks []check) bool {
+	fixed := false
+	for _, c := range checks {
+		if c.Status == statusPass || c.Status == statusSkip {
+			continue
+		}
+		switch c.Name {
+		case "Extensions":
+			missing, err := ctx.MissingExtensions()
+			if err != nil {
+				continue
+			}
+			for _, ext := range missing {
+				fmt.Fprintf(os.Stderr, "Installing %s...\n", ext)
+				install := exec.Command("gh", "extension", "install", ext)
+				install.Stdout, install.Stderr = os.Stderr, os.Stderr
+				if err := install.Run(); err != nil {
+					clierr.Print(os.Stderr, fmt.Errorf("installing %s: %w", ext, err))
+					continue
+				}
+				fixed = true
+			}
+		case "Token scopes":
+			if !tui.IsInteractive(cmd) {
+				fmt.Fprintln(os.Stderr, "Skipping the token scopes, adding them needs an interactive terminal")
+				continue
+			}
+			if err := ctx.RefreshScopes(); err != nil {
+				clierr.Print(os.Stderr, fmt.Errorf("refreshing the token: %w", err))
+				continue
+			}
+			fixed = true
+		}
+	}
+	return fixed
+}
+
+// megabytes formats a size in bytes, e.g. "12.3 MB"
+func megabytes(size int64) string {
+	return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+}
@@ -0,0 +1,26 @@
+package doctor
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that gh pm is set up to work with your projects",
+		Long: `Check the gh version, the login and token scopes, git, the gh extensions gh pm
+relies on, the config file, the local cache and whether the GitHub API answers,
+printing how to fix each failed check. Exits with status 1 when a check fails;
+warnings do not count.
+
+Attach the output of --json to bug reports.`,
+		Example: `  gh pm doctor
+  gh pm doctor --fix
+  gh pm doctor --json > doctor.json`,
+		Args: cobra.NoArgs,
+		Run:  doctorAction,
+	}
+	cmd.Flags().Bool("json", false, "Print the report as JSON")
+	cmd.Flags().Bool("fix", false, "Install missing extensions and add missing token scopes, then check again")
+	return cmd
+}
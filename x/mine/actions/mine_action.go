@@ -0,0 +1,84 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/config"
+	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/mine/views"
+	"github.com/spf13/cobra"
+)
+
+// MineAction handles the 'mine' command
+func MineAction(cmd *cobra.Command, args []string) {
+	opts, err := options(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if _, err := filter.Parse(opts.Filter); err != nil {
+		clierr.Exit(clierr.Invalidf("invalid filter: %w", err))
+	}
+
+	if !tui.IsInteractive(cmd) {
+		// Stop the requests in flight on ctrl+c
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		list, err := views.Collect(ctx, opts)
+		if err := ctx.Err(); err != nil {
+			clierr.Exit(err)
+		}
+		if err != nil && len(list) == 0 {
+			clierr.Exit(err)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: some projects could not be loaded:\n%v\n", err)
+		}
+		if err := views.PrintWork(os.Stdout, views.Arrange(list, opts), opts); err != nil {
+			clierr.Exit(err)
+		}
+		return
+	}
+
+	p := tea.NewProgram(views.NewMineModel(opts), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		clierr.Exit(fmt.Errorf("running program: %w", err))
+	}
+}
+
+// options reads the flags, falling back to the mine section of the config
+// file, and lists the viewer and their organizations when no owner is given
+func options(cmd *cobra.Command) (views.Options, error) {
+	c, err := config.Load()
+	if err != nil {
+		return views.Options{}, err
+	}
+	opts := views.Options{Priority: c.Mine.Priority, Due: c.Mine.Due, Workers: c.Concurrency()}
+	opts.Owners, _ = cmd.Flags().GetStringSlice("owner")
+	opts.Filter, _ = cmd.Flags().GetString("filter")
+	opts.All, _ = cmd.Flags().GetBool("all")
+	opts.Cached, _ = cmd.Flags().GetBool("cached")
+	if cmd.Flags().Changed("workers") {
+		opts.Workers, _ = cmd.Flags().GetInt("workers")
+	}
+	if cmd.Flags().Changed("priority") {
+		opts.Priority, _ = cmd.Flags().GetString("priority")
+	}
+	if cmd.Flags().Changed("due") {
+		opts.Due, _ = cmd.Flags().GetString("due")
+	}
+	if len(opts.Owners) == 0 {
+		orgs, err := ctx.Orgs(cmd)
+		if err != nil {
+			return views.Options{}, fmt.Errorf("listing your organizations: %w", err)
+		}
+		opts.Owners = append([]string{""}, orgs...)
+	}
+	return opts, nil
+}
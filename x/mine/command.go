@@ -0,0 +1,34 @@
+package mine
+
+import (
+	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/x/mine/actions"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mine",
+		Short: "Show the open items assigned to you across your projects",
+		Long: `Show the open items assigned to you across the projects of your account and
+of your organizations, grouped by project and Status and sorted by priority and
+due date. Issues and pull requests assigned to you that are on none of these
+projects are listed last. In a terminal, items can be moved to another Status
+without opening their board.
+
+The priority and due date fields default to the mine section of the config file.`,
+		Example: `  gh pm mine
+  gh pm mine --owner my-org --filter 'label:bug'
+  gh pm mine --cached --no-tui`,
+		Run:         actions.MineAction,
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeReadProject},
+	}
+	cmd.Flags().StringSlice("owner", nil, "Users or organizations whose projects are searched (defaults to you and your organizations)")
+	cmd.Flags().String("filter", "", "Only show items matching this filter, e.g. 'label:bug status:Todo'")
+	cmd.Flags().Bool("all", false, "Include closed issues and merged pull requests")
+	cmd.Flags().String("priority", "", "Single select or number field ranking items (default Priority)")
+	cmd.Flags().String("due", "", "Date field holding due dates (defaults to the first date field named like due)")
+	cmd.Flags().Bool("cached", false, "Read the projects from the local cache instead of the API")
+	cmd.Flags().Int("workers", 0, "Number of API requests run at the same time (default 4, or the workers setting)")
+	return cmd
+}
@@ -0,0 +1,392 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	projectviews "github.com/prnk28/gh-pm/x/project/views"
+)
+
+var (
+	mineProjectStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	mineStatusStyle   = lipgloss.NewStyle().Bold(true)
+	mineSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#2D3142"))
+	mineMutedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+// foundMsg carries the next result of a Load; ok is false once it finished
+type foundMsg struct {
+	results <-chan Found
+	found   Found
+	ok      bool
+}
+
+// statusSavedMsg reports the result of changing the Status of an item; the
+// item goes back to before on error
+type statusSavedMsg struct {
+	before Assignment
+	err    error
+}
+
+// statusPicker lists the Status options an item can move to
+type statusPicker struct {
+	assignment Assignment
+	field      models.Field
+	cursor     int
+}
+
+// MineModel lists the work assigned to the viewer grouped by project and
+// Status, and moves items to another Status
+type MineModel struct {
+	opts    Options
+	rec     *journal.Recorder
+	spinner tui.Spinner
+	// loading is set while results of a Load are coming in on results;
+	// cancel stops that Load
+	loading bool
+	results <-chan Found
+	cancel  context.CancelFunc
+	loaded  int
+	errs    []error
+	list    []Assignment
+	groups  []Group
+	// rows are the assignments in display order, cursor indexes them
+	rows   []Assignment
+	cursor int
+	picker *statusPicker
+	saving int
+	status string
+	width  int
+	height int
+}
+
+// NewMineModel creates the view and starts loading the assignments of opts,
+// which show up project by project
+func NewMineModel(opts Options) MineModel {
+	m := MineModel{
+		opts:    opts,
+		rec:     journal.Begin("mine"),
+		spinner: tui.NewSpinner("Loading your projects..."),
+	}
+	m.start()
+	return m
+}
+
+// Init waits for the first results
+func (m MineModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Init(), m.next)
+}
+
+// start cancels a running Load and starts a new one from scratch
+func (m *MineModel) start() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan Found)
+	go Load(ctx, m.opts, results)
+	m.cancel, m.results = cancel, results
+	m.loading, m.loaded, m.errs, m.list = true, 0, nil, nil
+	m.arrange(Assignment{})
+}
+
+// next waits for the next result of the running Load
+func (m MineModel) next() tea.Msg {
+	found, ok := <-m.results
+	return foundMsg{results: m.results, found: found, ok: ok}
+}
+
+// quit stops loading and exits
+func (m MineModel) quit() (tea.Model, tea.Cmd) {
+	m.cancel()
+	return m, tea.Quit
+}
+
+// Update handles messages for the model
+func (m MineModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case foundMsg:
+		if msg.results != m.results {
+			// Left over from a Load that was restarted
+			return m, nil
+		}
+		if !msg.ok {
+			m.loading = false
+			m.status = summary(m.groups)
+			if len(m.errs) > 0 {
+				m.status = fmt.Sprintf("Could not load everything: %v", m.errs[0])
+				if len(m.errs) > 1 {
+					m.status += fmt.Sprintf(" (and %d more)", len(m.errs)-1)
+				}
+			}
+			return m, nil
+		}
+		m.loaded++
+		if msg.found.Err != nil {
+			m.errs = append(m.errs, msg.found.Err)
+		}
+		if len(msg.found.List) > 0 {
+			keep := Assignment{}
+			if m.cursor < len(m.rows) {
+				keep = m.rows[m.cursor]
+			}
+			m.list = append(slices.Clone(m.list), msg.found.List...)
+			m.arrange(keep)
+		}
+		return m, m.next
+
+	case statusSavedMsg:
+		m.saving--
+		if msg.err != nil {
+			m.replace(msg.before)
+			m.status = fmt.Sprintf("Could not update %s: %v", msg.before.Item.Ref(), msg.err)
+			return m, nil
+		}
+		if m.saving == 0 {
+			m.status = "All changes saved"
+			if err := m.rec.Err(); err != nil {
+				m.status += fmt.Sprintf(", but they cannot be undone: %v", err)
+			}
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.picker != nil {
+			return m.updatePicker(msg)
+		}
+		switch msg.String() {
+		case "ctrl+c":
+			return m.quit()
+		case "q", "esc":
+			if m.saving > 0 {
+				m.status = "Waiting for changes to be saved..."
+				return m, nil
+			}
+			return m.quit()
+		}
+		switch msg.String() {
+		case "up", "k":
+			m.cursor = max(m.cursor-1, 0)
+		case "down", "j":
+			m.cursor = min(m.cursor+1, max(len(m.rows)-1, 0))
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = max(len(m.rows)-1, 0)
+		case "r":
+			if m.saving > 0 {
+				return m, nil
+			}
+			m.start()
+			return m, tea.Batch(m.spinner.Init(), m.next)
+		case "s", "enter":
+			m.openPicker()
+		}
+		return m, nil
+	}
+
+	if m.loading {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// openPicker offers the Status options of the project of the selected item
+func (m *MineModel) openPicker() {
+	if m.cursor >= len(m.rows) {
+		return
+	}
+	a := m.rows[m.cursor]
+	if a.Project == nil {
+		m.status = fmt.Sprintf("%s is not on a project, add it to one to give it a Status", a.Item.Ref())
+		return
+	}
+	field := a.Project.Field(models.StatusField)
+	if field == nil || len(field.Options) == 0 {
+		m.status = fmt.Sprintf("%s has no Status field", a.ProjectName())
+		return
+	}
+	cursor := slices.IndexFunc(field.Options, func(o models.FieldOption) bool { return o.Name == a.Status() })
+	m.picker = &statusPicker{assignment: a, field: *field, cursor: max(cursor, 0)}
+}
+
+func (m MineModel) updatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.picker
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case "esc", "q":
+		m.picker = nil
+	case "up", "k":
+		p.cursor = max(p.cursor-1, 0)
+	case "down", "j":
+		p.cursor = min(p.cursor+1, len(p.field.Options)-1)
+	case "enter", " ":
+		m.picker = nil
+		option := p.field.Options[p.cursor]
+		if option.Name == p.assignment.Status() {
+			return m, nil
+		}
+		return m, m.setStatus(p.assignment, p.field, option)
+	}
+	return m, nil
+}
+
+// setStatus moves a to option right away and saves the change in the background
+func (m *MineModel) setStatus(a Assignment, field models.Field, option models.FieldOption) tea.Cmd {
+	change := projectviews.Change{Fields: []projectviews.FieldChange{{
+		Field: field,
+		Value: models.FieldValue{Text: option.Name, OptionID: option.ID},
+	}}}
+	after := a
+	after.Item = change.ApplyTo(a.Item)
+	m.replace(after)
+	m.saving++
+	m.status = fmt.Sprintf("Moving %s to %s...", a.Item.Ref(), option.Name)
+
+	rec := m.rec
+	return func() tea.Msg {
+		return statusSavedMsg{before: a, err: change.Apply(rec, a.Project.ID, a.Item)}
+	}
+}
+
+// replace swaps in the new version of an assignment and regroups, keeping the
+// cursor on it
+func (m *MineModel) replace(a Assignment) {
+	m.list = slices.Clone(m.list)
+	for i, old := range m.list {
+		if old.Project == a.Project && old.Item.ID == a.Item.ID {
+			m.list[i] = a
+		}
+	}
+	m.arrange(a)
+}
+
+// arrange regroups the assignments and moves the cursor to keep, or keeps its
+// position when keep is not found
+func (m *MineModel) arrange(keep Assignment) {
+	m.groups = Arrange(m.list, m.opts)
+	m.rows = nil
+	for _, g := range m.groups {
+		m.rows = append(m.rows, g.Items...)
+	}
+	if i := slices.IndexFunc(m.rows, func(a Assignment) bool {
+		return a.Project == keep.Project && a.Item.ID == keep.Item.ID && keep.Item.ID != ""
+	}); i >= 0 {
+		m.cursor = i
+	}
+	m.cursor = max(min(m.cursor, len(m.rows)-1), 0)
+}
+
+// View renders the model
+func (m MineModel) View() string {
+	title := "My work"
+	if m.picker != nil {
+		return tui.Header(title) + "\n\n" + m.pickerView()
+	}
+
+	width := max(m.width, 40)
+	var lines []string
+	cursorLine := 0
+	row, project := 0, ""
+	for _, g := range m.groups {
+		if g.Project != project {
+			if project != "" {
+				lines = append(lines, "")
+			}
+			project = g.Project
+			lines = append(lines, mineProjectStyle.Render(text.Truncate(width, g.Project)))
+		}
+		if g.Status != "" {
+			lines = append(lines, mineStatusStyle.Render(fmt.Sprintf("  %s · %d", g.Status, len(g.Items))))
+		}
+		for _, a := range g.Items {
+			if row == m.cursor {
+				cursorLine = len(lines)
+			}
+			lines = append(lines, m.renderItem(a, width-4, row == m.cursor))
+			row++
+		}
+	}
+	if len(m.rows) == 0 && !m.loading {
+		lines = append(lines, mineMutedStyle.Render("Nothing is assigned to you"))
+	}
+
+	// Scroll so the cursor stays visible
+	rows := max(m.height-5, 5)
+	start := max(cursorLine-rows+1, 0)
+	lines = lines[start:min(start+rows, len(lines))]
+
+	return strings.Join([]string{
+		tui.Header(title),
+		strings.Join(lines, "\n"),
+		"",
+		m.statusLine(),
+		tui.Footer("↑/↓: Navigate • s/Enter: Set status • r: Refresh • q: Quit"),
+	}, "\n")
+}
+
+// statusLine shows the loading progress while results come in
+func (m MineModel) statusLine() string {
+	if !m.loading {
+		return m.status
+	}
+	line := m.spinner.View() + fmt.Sprintf(" %d loaded", m.loaded)
+	if m.status != "" {
+		line += " • " + m.status
+	}
+	return line
+}
+
+func (m MineModel) renderItem(a Assignment, width int, selected bool) string {
+	line := a.Item.Ref() + " " + a.Item.Title
+	var tags []string
+	if p := Priority(a, m.opts.Priority); p != "" {
+		tags = append(tags, p)
+	}
+	if due := Due(a, m.opts.Due); due != "" {
+		tags = append(tags, "due "+due)
+	}
+	if len(tags) > 0 {
+		line += " " + mineMutedStyle.Render("["+strings.Join(tags, " · ")+"]")
+	}
+	line = "    " + text.Truncate(width, line)
+	if selected {
+		return mineSelectedStyle.Render(line)
+	}
+	return line
+}
+
+func (m MineModel) pickerView() string {
+	p := m.picker
+	lines := []string{
+		fmt.Sprintf("Move %s %s to:", p.assignment.Item.Ref(), p.assignment.Item.Title),
+		"",
+	}
+	for i, o := range p.field.Options {
+		line := "  " + o.Name
+		if o.Name == p.assignment.Status() {
+			line += mineMutedStyle.Render(" (current)")
+		}
+		if i == p.cursor {
+			line = mineSelectedStyle.Render("> " + o.Name)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n") + "\n\n" + tui.Footer("↑/↓: Choose • Enter: Move • Esc: Cancel")
+}
@@ -0,0 +1,427 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/store"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+// searchLimit bounds the assigned issues and pull requests looked up outside
+// of projects
+const searchLimit = 100
+
+// Options selects the items that count as the viewer's work and how they rank
+type Options struct {
+	// Owners are the users and organizations whose projects are searched
+	Owners []string
+	// Filter further narrows the items, in the syntax of the filter package
+	Filter string
+	// All keeps closed issues and merged pull requests
+	All bool
+	// Priority is a single select or number field ranking items, lower options
+	// and numbers first
+	Priority string
+	// Due is a date field holding due dates, see Config.Mine
+	Due string
+	// Cached reads projects from the local cache instead of the API
+	Cached bool
+	// Workers bounds the API requests run at the same time
+	Workers int
+}
+
+// Query returns the filter matching the items assigned to the viewer
+func (o Options) Query() (filter.Query, error) {
+	q := "assignee:@me -is:archived"
+	if !o.All {
+		q += " is:open"
+	}
+	return filter.Parse(q + " " + o.Filter)
+}
+
+// Assignment is an item assigned to the viewer. Project is nil for issues and
+// pull requests that are not on any of the searched projects.
+type Assignment struct {
+	Project *models.Project
+	Item    models.Item
+}
+
+// Status returns the Status of the item in its project
+func (a Assignment) Status() string {
+	return a.Item.Status()
+}
+
+// ProjectName returns "owner/number title", or "Not in a project"
+func (a Assignment) ProjectName() string {
+	if a.Project == nil {
+		return "Not in a project"
+	}
+	return fmt.Sprintf("%s/%d %s", a.Project.Owner, a.Project.Number, a.Project.Title)
+}
+
+// Found is the assignments of one project, or of the work outside of
+// projects, or the error that kept them from being loaded
+type Found struct {
+	List []Assignment
+	Err  error
+}
+
+// Load sends the assignments to out as each project finishes loading and
+// closes out when done or once ctx is cancelled. The projects of opts.Owners,
+// where an empty owner is the viewer, are read from the local cache or through
+// the API with opts.Workers requests at the same time. The API also provides
+// the issues and pull requests assigned to the viewer outside of those
+// projects, which are sent last. Projects that cannot be read do not stop the
+// others.
+func Load(ctx context.Context, opts Options, out chan<- Found) {
+	defer close(out)
+	send := func(f Found) {
+		select {
+		case out <- f:
+		case <-ctx.Done():
+		}
+	}
+	query, err := opts.Query()
+	if err != nil {
+		send(Found{Err: err})
+		return
+	}
+	if opts.Cached {
+		list, _, err := LoadCached(opts)
+		send(Found{List: list, Err: err})
+		return
+	}
+	user, err := ghc.GetWhoami()
+	if err != nil {
+		send(Found{Err: err})
+		return
+	}
+	viewer := user.Login
+	owners := make([]string, 0, len(opts.Owners))
+	for _, owner := range opts.Owners {
+		if owner == "" || owner == "@me" {
+			owner = viewer
+		}
+		owners = append(owners, owner)
+	}
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	var found []models.Item
+	var searchErr error
+	pool := ghc.NewPool(ctx, opts.Workers)
+	ghc.FetchProjects(pool, owners, false, func(r ghc.ProjectResult) {
+		if r.Err != nil {
+			send(Found{Err: r.Err})
+			return
+		}
+		env := filter.Env{Viewer: viewer, Project: r.Project}
+		var list []Assignment
+		for _, item := range r.Items {
+			if query.Match(item, env) {
+				list = append(list, Assignment{Project: r.Project, Item: item})
+			}
+		}
+		mu.Lock()
+		for _, a := range list {
+			seen[a.Item.ContentID] = true
+		}
+		mu.Unlock()
+		send(Found{List: list})
+	})
+	// Assigned work that no project tracks, narrowed by the same filter
+	pool.Go(func(ctx context.Context) {
+		search := "assignee:@me archived:false"
+		if !opts.All {
+			search += " is:open"
+		}
+		found, searchErr = ghc.SearchContentContext(ctx, search, searchLimit)
+	})
+	pool.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+	if searchErr != nil {
+		send(Found{Err: fmt.Errorf("searching issues and pull requests: %w", searchErr)})
+		return
+	}
+	var list []Assignment
+	env := filter.Env{Viewer: viewer}
+	for _, item := range found {
+		item.Assignees = []string{viewer}
+		if !seen[item.ContentID] && query.Match(item, env) {
+			list = append(list, Assignment{Item: item})
+		}
+	}
+	send(Found{List: list})
+}
+
+// Collect waits for everything Load finds. Errors are joined into the returned
+// error alongside the assignments that could be loaded.
+func Collect(ctx context.Context, opts Options) ([]Assignment, error) {
+	out := make(chan Found)
+	go Load(ctx, opts, out)
+	var list []Assignment
+	var errs []error
+	for f := range out {
+		list = append(list, f.List...)
+		if f.Err != nil {
+			errs = append(errs, f.Err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return list, errors.Join(errs...)
+}
+
+// LoadCached reads the assignments from the projects of opts.Owners in the
+// local cache, and returns them with the viewer login recorded by the last
+// sync. Work outside of the synced projects is not listed.
+func LoadCached(opts Options) ([]Assignment, string, error) {
+	query, err := opts.Query()
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := store.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	defer s.Close()
+
+	viewer, err := s.Meta(store.MetaViewer)
+	if err != nil {
+		return nil, "", err
+	}
+	owners := map[string]bool{}
+	for _, owner := range opts.Owners {
+		if owner == "" || owner == "@me" {
+			owner = viewer
+		}
+		owners[strings.ToLower(owner)] = true
+	}
+	projects, err := s.Projects()
+	if err != nil {
+		return nil, "", err
+	}
+	var list []Assignment
+	for i := range projects {
+		project := &projects[i]
+		if project.Closed || !owners[strings.ToLower(project.Owner)] {
+			continue
+		}
+		where, args, err := query.SQL(filter.Env{Viewer: viewer, Project: project, Now: time.Now()})
+		if err != nil {
+			return nil, "", err
+		}
+		items, err := s.Items(project.ID, where, args...)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, item := range items {
+			list = append(list, Assignment{Project: project, Item: item})
+		}
+	}
+	return list, viewer, nil
+}
+
+// Group is the assignments of one project sharing a Status
+type Group struct {
+	Project string
+	Status  string
+	Items   []Assignment
+}
+
+// Arrange sorts assignments by project, then by Status in the option order of
+// the project, then by priority and due date, and splits them into groups.
+// Work outside of projects comes last.
+func Arrange(list []Assignment, opts Options) []Group {
+	list = slices.Clone(list)
+	sort.SliceStable(list, func(i, j int) bool {
+		return compareAssignments(list[i], list[j], opts) < 0
+	})
+	var groups []Group
+	for _, a := range list {
+		project, status := a.ProjectName(), a.Status()
+		if status == "" && a.Project != nil {
+			status = "No Status"
+		}
+		if n := len(groups); n == 0 || groups[n-1].Project != project || groups[n-1].Status != status {
+			groups = append(groups, Group{Project: project, Status: status})
+		}
+		groups[len(groups)-1].Items = append(groups[len(groups)-1].Items, a)
+	}
+	return groups
+}
+
+func compareAssignments(a, b Assignment, opts Options) int {
+	switch {
+	case a.Project == nil || b.Project == nil:
+		if a.Project != b.Project {
+			// Work outside of projects comes last
+			if a.Project == nil {
+				return 1
+			}
+			return -1
+		}
+	case a.Project.ID != b.Project.ID:
+		if c := strings.Compare(strings.ToLower(a.Project.Owner), strings.ToLower(b.Project.Owner)); c != 0 {
+			return c
+		}
+		return a.Project.Number - b.Project.Number
+	default:
+		if c := optionRank(a.Project.Field(models.StatusField), a.Status()) - optionRank(b.Project.Field(models.StatusField), b.Status()); c != 0 {
+			return c
+		}
+	}
+	if c := comparePriority(a, b, opts.Priority); c != 0 {
+		return c
+	}
+	// Dates in 2006-01-02 form order correctly as strings; undated items last
+	da, db := Due(a, opts.Due), Due(b, opts.Due)
+	switch {
+	case da == db:
+	case da == "":
+		return 1
+	case db == "":
+		return -1
+	default:
+		return strings.Compare(da, db)
+	}
+	return b.Item.UpdatedAt.Compare(a.Item.UpdatedAt)
+}
+
+// optionRank returns the position of value among the options of a single
+// select field, placing values that are empty or unknown last
+func optionRank(field *models.Field, value string) int {
+	if field == nil {
+		return 0
+	}
+	for i, o := range field.Options {
+		if o.Name == value {
+			return i
+		}
+	}
+	return len(field.Options)
+}
+
+// priorityField returns the field named name ("Priority" when empty) on the
+// project of a
+func priorityField(a Assignment, name string) *models.Field {
+	if a.Project == nil {
+		return nil
+	}
+	if name == "" {
+		name = "Priority"
+	}
+	for i, f := range a.Project.Fields {
+		if strings.EqualFold(f.Name, name) && (f.Type == models.FieldTypeSingleSelect || f.Type == models.FieldTypeNumber) {
+			return &a.Project.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Priority returns the priority of a, or "" when it has none
+func Priority(a Assignment, name string) string {
+	if f := priorityField(a, name); f != nil {
+		return a.Item.Value(f.Name)
+	}
+	return ""
+}
+
+// comparePriority ranks prioritized items first. Items of different projects
+// compare by option position or number, so P0 ranks with P0 across projects.
+func comparePriority(a, b Assignment, name string) int {
+	rank := func(a Assignment) (float64, bool) {
+		f := priorityField(a, name)
+		if f == nil || a.Item.Value(f.Name) == "" {
+			return 0, false
+		}
+		if f.Type == models.FieldTypeNumber {
+			return a.Item.Values[f.Name].Number, true
+		}
+		return float64(optionRank(f, a.Item.Value(f.Name))), true
+	}
+	ra, oka := rank(a)
+	rb, okb := rank(b)
+	switch {
+	case !oka && !okb:
+		return 0
+	case !oka:
+		return 1
+	case !okb:
+		return -1
+	case ra < rb:
+		return -1
+	case ra > rb:
+		return 1
+	}
+	return 0
+}
+
+// Due returns the due date of a as 2006-01-02, or "" when it has none. The
+// field is named by name, or is the first date field with "due" in its name.
+func Due(a Assignment, name string) string {
+	if a.Project == nil {
+		return ""
+	}
+	for _, f := range a.Project.Fields {
+		if f.Type != models.FieldTypeDate {
+			continue
+		}
+		if (name == "" && strings.Contains(strings.ToLower(f.Name), "due")) || strings.EqualFold(f.Name, name) {
+			return a.Item.Value(f.Name)
+		}
+	}
+	return ""
+}
+
+// PrintWork writes the groups as a table with one row per item
+func PrintWork(w io.Writer, groups []Group, opts Options) error {
+	t := tui.NewTablePrinter(w, "PROJECT", "STATUS", "ITEM", "TITLE", "PRIORITY", "DUE")
+	for _, g := range groups {
+		for _, a := range g.Items {
+			t.AddField(g.Project)
+			t.AddField(g.Status)
+			t.AddField(a.Item.Ref())
+			t.AddField(a.Item.Title)
+			t.AddField(Priority(a, opts.Priority))
+			t.AddField(Due(a, opts.Due))
+			t.EndRow()
+		}
+	}
+	return t.Render()
+}
+
+// Count returns the number of items in groups
+func Count(groups []Group) int {
+	n := 0
+	for _, g := range groups {
+		n += len(g.Items)
+	}
+	return n
+}
+
+// summary describes the number of items and projects in groups
+func summary(groups []Group) string {
+	projects := map[string]bool{}
+	for _, g := range groups {
+		if g.Items[0].Project != nil {
+			projects[g.Project] = true
+		}
+	}
+	return strconv.Itoa(Count(groups)) + " items in " + strconv.Itoa(len(projects)) + " projects"
+}
@@ -0,0 +1,87 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/spec"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// ApplyAction handles the 'project apply' command
+func ApplyAction(cmd *cobra.Command, args []string) {
+	s, err := spec.Load(args[0])
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("reading %s: %w", args[0], err))
+	}
+	owner := s.Owner
+	if cmd.Flags().Changed("owner") {
+		owner, _ = cmd.Flags().GetString("owner")
+	}
+	number := s.Number
+	if cmd.Flags().Changed("number") {
+		number, _ = cmd.Flags().GetInt("number")
+	}
+	create, _ := cmd.Flags().GetBool("create")
+	prune, _ := cmd.Flags().GetBool("prune")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	var project *models.Project
+	var views []models.ProjectView
+	if !create && number > 0 {
+		project, err = ghc.GetProject(owner, number)
+		if errors.Is(err, ghc.ErrNotFound) {
+			clierr.Exit(clierr.New(clierr.KindNotFound, err, "pass --create to create a new project from the file"))
+		}
+		if err != nil {
+			clierr.Exit(err)
+		}
+		views, err = ghc.GetProjectViews(project.Owner, number)
+		if err != nil {
+			clierr.Exit(err)
+		}
+	}
+
+	plan := spec.NewPlan(owner, project, views, s, prune)
+	plan.Print(os.Stdout)
+	if plan.Changes() == 0 || dryRun {
+		return
+	}
+	fmt.Println()
+
+	if !yes {
+		if !tui.IsInteractive(cmd) {
+			clierr.Exit(clierr.Invalidf("pass --yes to apply the plan in non-interactive mode"))
+		}
+		confirmed := false
+		err := huh.NewConfirm().
+			Title(fmt.Sprintf("Apply %d changes?", plan.Changes())).
+			Value(&confirmed).
+			Run()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled")
+			return
+		}
+	}
+
+	rec := journal.Begin("project apply")
+	project, err = plan.Apply(rec)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the changes were not recorded and cannot be undone: %v\n", err)
+	}
+	fmt.Printf("Applied %s to %s\n", args[0], project.URL)
+}
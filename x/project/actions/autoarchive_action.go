@@ -0,0 +1,40 @@
+package actions
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// AutoArchiveAction handles the 'project autoarchive' command
+func AutoArchiveAction(cmd *cobra.Command, args []string) {
+	status, _ := cmd.Flags().GetString("status")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	extra, _ := cmd.Flags().GetString("filter")
+
+	if _, err := (filter.Env{}).Time(olderThan); err != nil {
+		clierr.Exit(clierr.Invalidf("invalid --older-than: %w", err))
+	}
+	query := "status:" + strconv.Quote(status) + " updated:<" + olderThan + " " + extra
+	q, err := filter.Parse(query)
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid filter: %w", err))
+	}
+
+	project := loadProject(cmd, args)
+	items, err := matchingItems(project, q, false)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if len(items) == 0 {
+		fmt.Printf("No %s items older than %s to archive\n", status, olderThan)
+		return
+	}
+
+	archive := true
+	runBulk(cmd, "project autoarchive", project, items, views.Change{Archive: &archive})
+}
@@ -4,23 +4,50 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/prnk28/gh-pm/internal/clierr"
 	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/spec"
+	"github.com/prnk28/gh-pm/internal/tui"
 	"github.com/prnk28/gh-pm/x/project/views"
 	"github.com/spf13/cobra"
 )
 
 // CreateAction handles the 'project create' command
 func CreateAction(cmd *cobra.Command, args []string) {
-	c, err := ctx.Get(cmd)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	form := &views.ProjectForm{}
+	form.Title, _ = cmd.Flags().GetString("title")
+	form.Organization, _ = cmd.Flags().GetString("owner")
+	form.Description, _ = cmd.Flags().GetString("description")
+	form.TemplateOwner, _ = cmd.Flags().GetString("template-owner")
+	form.TemplateNumber, _ = cmd.Flags().GetInt("template-project")
+	form.CopyDrafts, _ = cmd.Flags().GetBool("drafts")
+	form.TemplatePath, _ = cmd.Flags().GetString("template-file")
+	switch {
+	case form.TemplateNumber > 0 && form.TemplatePath != "":
+		clierr.Exit(clierr.Invalidf("pass either --template-project or --template-file, not both"))
+	case form.TemplateNumber > 0:
+		form.Template = views.TemplateProject
+	case form.TemplatePath != "":
+		form.Template = views.TemplateYAML
 	}
-	// Create and run the form
-	form, err := views.NewProjectForm(c)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+
+	// Flags skip the form entirely, which is the only option without a terminal
+	if form.Title != "" {
+		form.Submitted = true
+	} else if !tui.IsInteractive(cmd) {
+		clierr.Exit(clierr.Invalidf("%v", tui.RequireFlags("project create", "--title", "--owner", "--description")))
+	} else {
+		c, err := ctx.Get(cmd)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		// Create and run the form
+		form, err = views.NewProjectForm(c)
+		if err != nil {
+			clierr.Exit(err)
+		}
 	}
 
 	// Check if the form was submitted or canceled
@@ -29,14 +56,73 @@ func CreateAction(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Create the project using the GitHub API
-	// This would be your actual implementation
-	// err = ghcli.CreateProject(form.Title, form.Organization, form.Description)
-	// if err != nil {
-	//     fmt.Fprintf(os.Stderr, "Error creating project: %v\n", err)
-	//     os.Exit(1)
-	// }
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		owner := form.Organization
+		if owner == "" {
+			owner = "@me"
+		}
+		fmt.Printf("Dry run: would create project %q owned by %s", form.Title, owner)
+		if template := form.TemplateName(); template != "" {
+			fmt.Printf(" from %s", template)
+		}
+		fmt.Println()
+		return
+	}
+
+	rec := journal.Begin("project create")
+	url, err := createProject(form, rec)
+	if err != nil {
+		clierr.Exit(fmt.Errorf("creating project: %w", err))
+	}
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the project was not recorded and cannot be undone: %v\n", err)
+	}
 
-	// For now, just simulate success
+	if !tui.IsInteractive(cmd) {
+		fmt.Println(url)
+		return
+	}
 	fmt.Println(form.FormatSummary())
 }
+
+// createProject creates the project described by form, starting from its
+// template, and returns its URL
+func createProject(form *views.ProjectForm, rec *journal.Recorder) (string, error) {
+	switch form.Template {
+	case views.TemplateProject:
+		project, err := ghc.CopyProject(form.TemplateOwner, form.TemplateNumber, form.Organization, form.Title, form.Description, form.CopyDrafts)
+		if err != nil {
+			return "", err
+		}
+		rec.ProjectCreated(form.Organization, int(project.Number), project.Id)
+		return project.Url, nil
+
+	case views.TemplateYAML:
+		s, err := spec.Load(form.TemplatePath)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", form.TemplatePath, err)
+		}
+		s.Title = form.Title
+		if form.Description != "" {
+			s.Description = form.Description
+		}
+		plan := spec.NewPlan(form.Organization, nil, nil, s, false)
+		project, err := plan.Apply(rec)
+		if err != nil {
+			return "", err
+		}
+		for _, step := range plan.Steps {
+			if step.Action == spec.ActionManual {
+				fmt.Fprintf(os.Stderr, "Note: %s\n", step.Summary)
+			}
+		}
+		return project.URL, nil
+	}
+
+	project, err := ghc.CreateProject(form.Organization, form.Title, form.Description)
+	if err != nil {
+		return "", err
+	}
+	rec.ProjectCreated(form.Organization, int(project.Number), project.Id)
+	return project.Url, nil
+}
@@ -0,0 +1,53 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// DeleteAction handles the 'project delete' command
+func DeleteAction(cmd *cobra.Command, args []string) {
+	yes, _ := cmd.Flags().GetBool("yes")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	project := loadProject(cmd, args)
+	if dryRun {
+		fmt.Printf("Dry run: would delete project %q (%s/%d) and all of its items\n", project.Title, project.Owner, project.Number)
+		return
+	}
+
+	if !yes {
+		if !tui.IsInteractive(cmd) {
+			clierr.Exit(clierr.Invalidf("pass --yes to delete a project in non-interactive mode"))
+		}
+		var typed string
+		err := huh.NewInput().
+			Title(fmt.Sprintf("Type %q to delete the project", project.Title)).
+			Description("Its items and fields are deleted too. This cannot be undone.").
+			Validate(func(s string) error {
+				if s != project.Title {
+					return errors.New("the title does not match")
+				}
+				return nil
+			}).
+			Value(&typed).
+			Run()
+		if err != nil {
+			clierr.Exit(err)
+		}
+	}
+
+	if err := ghc.DeleteProject(project.Owner, project.Number); err != nil {
+		clierr.Exit(err)
+	}
+	rec := journal.Begin("project delete")
+	rec.ProjectDeleted(project.Owner, project.Number, project.ID)
+	fmt.Printf("Deleted project %q\n", project.Title)
+}
@@ -0,0 +1,130 @@
+package actions
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// EditAction handles the 'project edit' command
+func EditAction(cmd *cobra.Command, args []string) {
+	project := loadProject(cmd, args)
+
+	values := map[string]string{}
+	for flag, setting := range map[string]string{
+		"title":       models.SettingTitle,
+		"description": models.SettingDescription,
+	} {
+		if cmd.Flags().Changed(flag) {
+			v, _ := cmd.Flags().GetString(flag)
+			values[setting] = strings.TrimSpace(v)
+		}
+	}
+	if cmd.Flags().Changed("readme-file") {
+		path, _ := cmd.Flags().GetString("readme-file")
+		readme, err := readInput(path)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		values[models.SettingReadme] = strings.TrimRight(readme, "\n")
+	}
+	if cmd.Flags().Changed("title") && values[models.SettingTitle] == "" {
+		clierr.Exit(clierr.Invalidf("the project needs a title"))
+	}
+
+	if len(values) == 0 {
+		if !tui.IsInteractive(cmd) {
+			clierr.Exit(clierr.Invalidf("cannot open an editor in non-interactive mode; pass --title, --description or --readme-file instead"))
+		}
+		var err error
+		values, err = editSettings(project)
+		if err != nil {
+			clierr.Exit(err)
+		}
+	}
+
+	changeSettings(cmd, "project edit", project, values)
+}
+
+// editSettings opens the title, description and readme of project in the
+// user's editor and returns the edited values
+func editSettings(project *models.Project) (map[string]string, error) {
+	path, err := views.WriteSettingsFile(project)
+	if err != nil {
+		return nil, err
+	}
+	editor := tui.EditorCommand(path)
+	editor.Stdin, editor.Stdout, editor.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := editor.Run(); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("running editor: %w", err)
+	}
+	return views.ReadSettingsFile(path, project)
+}
+
+// changeSettings changes the settings of project to values on behalf of
+// command, honouring --dry-run, and prints what changed
+func changeSettings(cmd *cobra.Command, command string, project *models.Project, values map[string]string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	var changes []string
+	for _, name := range models.Settings {
+		if value, ok := values[name]; ok && value != project.Setting(name) {
+			changes = append(changes, name)
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Printf("Nothing to change in %q\n", project.Title)
+		return
+	}
+	if dryRun {
+		for _, name := range changes {
+			fmt.Printf("Dry run: would change the %s of %q to %s\n", name, project.Title, describeSetting(name, values[name]))
+		}
+		return
+	}
+
+	rec := journal.Begin(command)
+	changed, err := views.SaveSettings(rec, project, values)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	for _, name := range changed {
+		fmt.Printf("Changed the %s of %q to %s\n", name, project.Title, describeSetting(name, values[name]))
+	}
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the change was not recorded and cannot be undone: %v\n", err)
+	}
+}
+
+// describeSetting shortens a setting value for a one line message
+func describeSetting(name, value string) string {
+	if name == models.SettingReadme {
+		lines := strings.Count(value, "\n") + 1
+		if value == "" {
+			lines = 0
+		}
+		return fmt.Sprintf("%d lines of text", lines)
+	}
+	return fmt.Sprintf("%q", value)
+}
+
+// readInput reads a file, or standard input when path is "-"
+func readInput(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	return string(data), err
+}
@@ -0,0 +1,32 @@
+package actions
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// ExportAction handles the 'project export' command
+func ExportAction(cmd *cobra.Command, args []string) {
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid project number %q", args[0]))
+	}
+	owner, _ := cmd.Flags().GetString("owner")
+
+	project, err := ghc.GetProject(owner, number)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	views, err := ghc.GetProjectViews(project.Owner, number)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if err := spec.FromProject(project, views).Write(os.Stdout); err != nil {
+		clierr.Exit(err)
+	}
+}
@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// loadProject fetches the project whose number is the first argument
+func loadProject(cmd *cobra.Command, args []string) *models.Project {
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid project number %q", args[0]))
+	}
+	owner, _ := cmd.Flags().GetString("owner")
+	project, err := ghc.GetProject(owner, number)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	return project
+}
+
+// findField returns the custom field of project named name, ignoring case
+func findField(project *models.Project, name string) (models.Field, error) {
+	fields := project.EditableFields()
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return f, nil
+		}
+		names = append(names, f.Name)
+	}
+	return models.Field{}, clierr.NotFoundf("project %q has no custom field named %q, expected one of %s", project.Title, name, strings.Join(names, ", "))
+}
+
+// parseOption parses a single select option written as name[:color[:description]]
+func parseOption(s string) (models.FieldOption, error) {
+	parts := strings.SplitN(s, ":", 3)
+	o := models.FieldOption{Name: strings.TrimSpace(parts[0])}
+	if o.Name == "" {
+		return o, clierr.Invalidf("invalid option %q, expected name[:color[:description]]", s)
+	}
+	if len(parts) > 1 && strings.TrimSpace(parts[1]) != "" {
+		color := strings.ToLower(strings.TrimSpace(parts[1]))
+		if !slices.Contains(spec.Colors, color) {
+			return o, clierr.Invalidf("option %q has unknown color %q, expected one of %s", o.Name, parts[1], strings.Join(spec.Colors, ", "))
+		}
+		o.Color = strings.ToUpper(color)
+	}
+	if len(parts) > 2 {
+		o.Description = strings.TrimSpace(parts[2])
+	}
+	return o, nil
+}
+
+// optionIndex returns the index of the option named name, ignoring case, or -1
+func optionIndex(options []models.FieldOption, name string) int {
+	return slices.IndexFunc(options, func(o models.FieldOption) bool { return strings.EqualFold(o.Name, name) })
+}
@@ -0,0 +1,90 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/spec"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// FieldCreateAction handles the 'project field create' command
+func FieldCreateAction(cmd *cobra.Command, args []string) {
+	name, _ := cmd.Flags().GetString("name")
+	typeName, _ := cmd.Flags().GetString("type")
+	options, _ := cmd.Flags().GetStringArray("option")
+	duration, _ := cmd.Flags().GetInt("duration")
+	startDay, _ := cmd.Flags().GetString("start-day")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	fieldType, err := spec.ParseFieldType(typeName)
+	if err != nil {
+		clierr.Exit(clierr.New(clierr.KindValidation, err, ""))
+	}
+	field := models.Field{Name: strings.TrimSpace(name), Type: fieldType}
+	if field.Name == "" {
+		clierr.Exit(clierr.Invalidf("pass --name"))
+	}
+
+	switch fieldType {
+	case models.FieldTypeSingleSelect:
+		if len(options) == 0 {
+			clierr.Exit(clierr.Invalidf("single select fields need at least one --option"))
+		}
+		for _, s := range options {
+			o, err := parseOption(s)
+			if err != nil {
+				clierr.Exit(err)
+			}
+			if optionIndex(field.Options, o.Name) >= 0 {
+				clierr.Exit(clierr.Invalidf("option %q is given twice", o.Name))
+			}
+			field.Options = append(field.Options, o)
+		}
+	case models.FieldTypeIteration:
+		day, err := spec.ParseWeekday(startDay)
+		if err != nil {
+			clierr.Exit(clierr.New(clierr.KindValidation, err, ""))
+		}
+		if duration < 1 {
+			clierr.Exit(clierr.Invalidf("--duration must be at least one day"))
+		}
+		field.IterationDuration = duration
+		field.IterationStartDay = day
+	default:
+		if len(options) > 0 {
+			clierr.Exit(clierr.Invalidf("%s fields have no options", typeName))
+		}
+	}
+
+	project := loadProject(cmd, args)
+	if _, err := findField(project, field.Name); err == nil {
+		clierr.Exit(clierr.Invalidf("project %q already has a field named %q", project.Title, field.Name))
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run: would create %s field %s in %q", views.FieldTypeName(field.Type), field.Name, project.Title)
+		if config := views.DescribeFieldConfig(field); config != "" {
+			fmt.Printf(": %s", config)
+		}
+		fmt.Println()
+		return
+	}
+
+	created, err := ghc.CreateField(project.ID, field)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	rec := journal.Begin("project field create")
+	rec.FieldCreated(project, *created)
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the field was not recorded and cannot be undone: %v\n", err)
+	}
+	fmt.Printf("Created %s field %s\n", views.FieldTypeName(created.Type), created.Name)
+}
@@ -0,0 +1,54 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// FieldDeleteAction handles the 'project field delete' command
+func FieldDeleteAction(cmd *cobra.Command, args []string) {
+	yes, _ := cmd.Flags().GetBool("yes")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	project := loadProject(cmd, args)
+	field, err := findField(project, args[1])
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if dryRun {
+		fmt.Printf("Dry run: would delete field %s of %q and its values on every item\n", field.Name, project.Title)
+		return
+	}
+
+	if !yes {
+		if !tui.IsInteractive(cmd) {
+			clierr.Exit(clierr.Invalidf("pass --yes to delete a field in non-interactive mode"))
+		}
+		confirmed := false
+		err := huh.NewConfirm().
+			Title(fmt.Sprintf("Delete field %s and its values on every item?", field.Name)).
+			Description("This cannot be undone.").
+			Value(&confirmed).
+			Run()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled")
+			return
+		}
+	}
+
+	if err := ghc.DeleteField(field.ID); err != nil {
+		clierr.Exit(err)
+	}
+	rec := journal.Begin("project field delete")
+	rec.FieldDeleted(project, field)
+	fmt.Printf("Deleted field %s\n", field.Name)
+}
@@ -0,0 +1,176 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// FieldEditAction handles the 'project field edit' command
+func FieldEditAction(cmd *cobra.Command, args []string) {
+	rename, _ := cmd.Flags().GetString("name")
+	options, _ := cmd.Flags().GetStringArray("option")
+	remove, _ := cmd.Flags().GetStringSlice("remove-option")
+	renames, _ := cmd.Flags().GetStringArray("rename-option")
+	order, _ := cmd.Flags().GetStringSlice("order")
+	yes, _ := cmd.Flags().GetBool("yes")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	project := loadProject(cmd, args)
+	before, err := findField(project, args[1])
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if before.Type != models.FieldTypeSingleSelect && (len(options) > 0 || len(remove) > 0 || len(renames) > 0 || len(order) > 0) {
+		clierr.Exit(clierr.Invalidf("%s is a %s field, only single select fields have options", before.Name, views.FieldTypeName(before.Type)))
+	}
+
+	after, changes, err := editField(before, rename, options, remove, renames, order)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if len(changes) == 0 {
+		fmt.Println("Nothing to change")
+		return
+	}
+	fmt.Printf("Changes to %s:\n", before.Name)
+	for _, c := range changes {
+		fmt.Printf("  %s\n", c)
+	}
+	if dryRun {
+		return
+	}
+
+	if len(remove) > 0 && !yes {
+		if !tui.IsInteractive(cmd) {
+			clierr.Exit(clierr.Invalidf("removing options clears them from items, pass --yes to confirm"))
+		}
+		confirmed := false
+		err := huh.NewConfirm().
+			Title("Remove the options and clear them from every item?").
+			Value(&confirmed).
+			Run()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled")
+			return
+		}
+	}
+
+	updated, err := ghc.UpdateField(after)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	rec := journal.Begin("project field edit")
+	rec.FieldUpdated(project, before, *updated)
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the change was not recorded and cannot be undone: %v\n", err)
+	}
+	fmt.Printf("Updated field %s\n", updated.Name)
+}
+
+// editField applies the edit flags to field and describes each change
+func editField(field models.Field, rename string, options, remove, renames, order []string) (models.Field, []string, error) {
+	var changes []string
+	field.Options = slices.Clone(field.Options)
+
+	if rename = strings.TrimSpace(rename); rename != "" && rename != field.Name {
+		changes = append(changes, fmt.Sprintf("rename to %s", rename))
+		field.Name = rename
+	}
+
+	for _, r := range renames {
+		from, to, ok := strings.Cut(r, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return field, nil, clierr.Invalidf("invalid --rename-option %q, expected Old=New", r)
+		}
+		i := optionIndex(field.Options, from)
+		if i < 0 {
+			return field, nil, clierr.NotFoundf("%s has no option %q", field.Name, from)
+		}
+		if j := optionIndex(field.Options, to); j >= 0 && j != i {
+			return field, nil, clierr.Invalidf("%s already has an option %q", field.Name, to)
+		}
+		changes = append(changes, fmt.Sprintf("~ option %s: rename to %s", field.Options[i].Name, to))
+		field.Options[i].Name = to
+	}
+
+	for _, s := range options {
+		o, err := parseOption(s)
+		if err != nil {
+			return field, nil, err
+		}
+		i := optionIndex(field.Options, o.Name)
+		if i < 0 {
+			if o.Color == "" {
+				o.Color = "GRAY"
+			}
+			field.Options = append(field.Options, o)
+			changes = append(changes, "+ option "+views.DescribeOption(o))
+			continue
+		}
+		existing := &field.Options[i]
+		if o.Color != "" && o.Color != existing.Color {
+			changes = append(changes, fmt.Sprintf("~ option %s: color %s → %s", existing.Name, strings.ToLower(existing.Color), strings.ToLower(o.Color)))
+			existing.Color = o.Color
+		}
+		if o.Description != "" && o.Description != existing.Description {
+			changes = append(changes, fmt.Sprintf("~ option %s: description %q → %q", existing.Name, existing.Description, o.Description))
+			existing.Description = o.Description
+		}
+	}
+
+	for _, name := range remove {
+		i := optionIndex(field.Options, name)
+		if i < 0 {
+			return field, nil, clierr.NotFoundf("%s has no option %q", field.Name, name)
+		}
+		changes = append(changes, "- option "+field.Options[i].Name)
+		field.Options = slices.Delete(field.Options, i, i+1)
+	}
+	if field.Type == models.FieldTypeSingleSelect && len(field.Options) == 0 {
+		return field, nil, clierr.Invalidf("%s needs at least one option", field.Name)
+	}
+
+	if len(order) > 0 {
+		// The options listed come first in the given order, the others keep their order after them
+		var ordered []models.FieldOption
+		for _, name := range order {
+			i := optionIndex(field.Options, name)
+			if i < 0 {
+				return field, nil, clierr.NotFoundf("%s has no option %q", field.Name, name)
+			}
+			if optionIndex(ordered, name) >= 0 {
+				return field, nil, clierr.Invalidf("option %q is listed twice in --order", name)
+			}
+			ordered = append(ordered, field.Options[i])
+		}
+		for _, o := range field.Options {
+			if optionIndex(ordered, o.Name) < 0 {
+				ordered = append(ordered, o)
+			}
+		}
+		if !slices.Equal(ordered, field.Options) {
+			names := make([]string, 0, len(ordered))
+			for _, o := range ordered {
+				names = append(names, o.Name)
+			}
+			changes = append(changes, "~ order options: "+strings.Join(names, ", "))
+			field.Options = ordered
+		}
+	}
+	return field, changes, nil
+}
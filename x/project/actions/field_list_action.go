@@ -0,0 +1,17 @@
+package actions
+
+import (
+	"os"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// FieldListAction handles the 'project field list' command
+func FieldListAction(cmd *cobra.Command, args []string) {
+	project := loadProject(cmd, args)
+	if err := views.PrintFields(os.Stdout, project); err != nil {
+		clierr.Exit(err)
+	}
+}
@@ -0,0 +1,126 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// ItemAddAction handles the 'project item add' command
+func ItemAddAction(cmd *cobra.Command, args []string) {
+	search, _ := cmd.Flags().GetString("search")
+	limit, _ := cmd.Flags().GetInt("limit")
+	yes, _ := cmd.Flags().GetBool("yes")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	urls := args[1:]
+	if len(urls) == 0 && search == "" {
+		clierr.Exit(clierr.Invalidf("pass the URLs of issues or pull requests, or --search"))
+	}
+
+	project := loadProject(cmd, args)
+	var contents []models.Item
+	for _, url := range urls {
+		content, err := ghc.GetContent(url)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		contents = append(contents, content)
+	}
+	if search != "" {
+		found, err := ghc.SearchContent(search, limit)
+		if err != nil {
+			clierr.Exit(fmt.Errorf("searching: %w", err))
+		}
+		contents = append(contents, found...)
+	}
+	if len(contents) == 0 {
+		fmt.Println("No issues or pull requests match the search")
+		return
+	}
+
+	existing, err := ghc.GetItems(project.ID)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	missing, present := views.SplitPresent(existing, contents)
+
+	t := tui.NewTablePrinter(os.Stdout, "ITEM", "TITLE", "STATE", "ACTION")
+	for _, c := range missing {
+		t.AddField(c.Ref())
+		t.AddField(c.Title)
+		t.AddField(c.State)
+		t.AddField("add")
+		t.EndRow()
+	}
+	for _, c := range present {
+		t.AddField(c.Ref())
+		t.AddField(c.Title)
+		t.AddField(c.State)
+		t.AddField("skip, already in the project")
+		t.EndRow()
+	}
+	if err := t.Render(); err != nil {
+		clierr.Exit(err)
+	}
+	fmt.Println()
+
+	if len(missing) == 0 {
+		fmt.Printf("Nothing to add, all %d items are already in %q\n", len(present), project.Title)
+		return
+	}
+	if dryRun {
+		fmt.Printf("Dry run: %d items would be added, %d are already in the project\n", len(missing), len(present))
+		return
+	}
+
+	// A search can match far more than expected, so it is confirmed first
+	if search != "" && !yes {
+		if !tui.IsInteractive(cmd) {
+			clierr.Exit(clierr.Invalidf("pass --yes to add search results in non-interactive mode"))
+		}
+		confirmed := false
+		err := huh.NewConfirm().
+			Title(fmt.Sprintf("Add %d items to %q?", len(missing), project.Title)).
+			Value(&confirmed).
+			Run()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled")
+			return
+		}
+	}
+
+	rec := journal.Begin("project item add")
+	results := views.AddItems(rec, project.ID, missing, func(r views.BulkResult) {
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to add %s: %v\n", r.Item.Ref(), r.Err)
+			return
+		}
+		fmt.Printf("Added %s %s\n", r.Item.Ref(), r.Item.Title)
+	})
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	fmt.Printf("\n%d added, %d already in the project, %d failed\n", len(results)-failed, len(present), failed)
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the changes were not recorded and cannot be undone: %v\n", err)
+	}
+	if failed > 0 {
+		os.Exit(clierr.ExitError)
+	}
+}
@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// ItemArchiveAction handles the 'project item archive' command
+func ItemArchiveAction(cmd *cobra.Command, args []string) {
+	archiveItems(cmd, args, true)
+}
+
+// ItemUnarchiveAction handles the 'project item unarchive' command
+func ItemUnarchiveAction(cmd *cobra.Command, args []string) {
+	archiveItems(cmd, args, false)
+}
+
+// archiveItems archives the items named by args or matching --filter, or
+// restores archived ones when archive is false
+func archiveItems(cmd *cobra.Command, args []string, archive bool) {
+	query, _ := cmd.Flags().GetString("filter")
+	q, err := filter.Parse(query)
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid filter: %w", err))
+	}
+	refs := args[1:]
+	if q.Empty() && len(refs) == 0 {
+		clierr.Exit(clierr.Invalidf("pass items such as owner/repo#12 or draft titles, or --filter to choose the items"))
+	}
+
+	project := loadProject(cmd, args)
+	// Archiving picks from the active items and unarchiving from the archived ones
+	items, err := matchingItems(project, q, !archive)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if len(refs) > 0 {
+		items, err = pickItems(items, refs)
+		if err != nil {
+			clierr.Exit(err)
+		}
+	}
+	if len(items) == 0 {
+		fmt.Println("No items match the filter")
+		return
+	}
+
+	command := "project item archive"
+	if !archive {
+		command = "project item unarchive"
+	}
+	runBulk(cmd, command, project, items, views.Change{Archive: &archive})
+}
+
+// pickItems returns the items referenced by refs, see findItem
+func pickItems(items []models.Item, refs []string) ([]models.Item, error) {
+	var picked []models.Item
+	for _, ref := range refs {
+		item, err := findItem(items, ref)
+		if err != nil {
+			return nil, err
+		}
+		picked = append(picked, item)
+	}
+	return picked, nil
+}
+
+// findItem returns the item referenced by ref: an issue or pull request as
+// owner/repo#12 or its URL, a draft issue by its title, or any item by its ID
+func findItem(items []models.Item, ref string) (models.Item, error) {
+	var drafts []models.Item
+	for _, item := range items {
+		switch {
+		case item.ID == ref:
+			return item, nil
+		case item.Type == models.ItemTypeDraftIssue:
+			if strings.EqualFold(item.Title, ref) {
+				drafts = append(drafts, item)
+			}
+		case strings.EqualFold(item.Ref(), ref) || item.URL == ref:
+			return item, nil
+		}
+	}
+	switch len(drafts) {
+	case 0:
+		return models.Item{}, clierr.NotFoundf("no matching item %s in the project", ref)
+	case 1:
+		return drafts[0], nil
+	}
+	return models.Item{}, clierr.Invalidf("%d drafts are titled %q, pass the item ID instead", len(drafts), ref)
+}
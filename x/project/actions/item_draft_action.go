@@ -0,0 +1,177 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// ItemAddDraftAction handles the 'project item add-draft' command
+func ItemAddDraftAction(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("title")
+	sets, _ := cmd.Flags().GetStringArray("set")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if strings.TrimSpace(title) == "" {
+		clierr.Exit(clierr.Invalidf("pass --title"))
+	}
+	body := draftBody(cmd)
+
+	project := loadProject(cmd, args)
+	var fields []views.FieldChange
+	for _, s := range sets {
+		fc, err := parseSet(project, s)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		fields = append(fields, fc)
+	}
+	if dryRun {
+		fmt.Printf("Dry run: would add draft %q to %q", title, project.Title)
+		if change := (views.Change{Fields: fields}); !change.Empty() {
+			fmt.Printf(" with %s", change)
+		}
+		fmt.Println()
+		return
+	}
+
+	rec := journal.Begin("project item add-draft")
+	item, err := views.AddDraft(rec, project, title, body, fields)
+	if item.ID != "" {
+		fmt.Printf("Added draft %q to %q\n", item.Title, project.Title)
+	}
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the draft was not recorded and cannot be undone: %v\n", err)
+	}
+}
+
+// ItemEditDraftAction handles the 'project item edit-draft' command
+func ItemEditDraftAction(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	var title, body *string
+	if cmd.Flags().Changed("title") {
+		v, _ := cmd.Flags().GetString("title")
+		title = &v
+	}
+	if cmd.Flags().Changed("body") || cmd.Flags().Changed("body-file") {
+		v := draftBody(cmd)
+		body = &v
+	}
+	if title == nil && body == nil {
+		clierr.Exit(clierr.Invalidf("nothing to change, pass --title, --body or --body-file"))
+	}
+
+	project := loadProject(cmd, args)
+	item := loadDraft(project, args[1])
+	if dryRun {
+		if title != nil {
+			fmt.Printf("Dry run: would rename draft %q to %q\n", item.Title, *title)
+		}
+		if body != nil {
+			fmt.Printf("Dry run: would replace the body of draft %q\n", item.Title)
+		}
+		return
+	}
+
+	rec := journal.Begin("project item edit-draft")
+	if _, err := views.EditDraft(rec, project.ID, item, title, body); err != nil {
+		clierr.Exit(err)
+	}
+	fmt.Printf("Updated draft %q\n", item.Title)
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the change was not recorded and cannot be undone: %v\n", err)
+	}
+}
+
+// ItemConvertAction handles the 'project item convert' command
+func ItemConvertAction(cmd *cobra.Command, args []string) {
+	repo, _ := cmd.Flags().GetString("repo")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	project := loadProject(cmd, args)
+	item := loadDraft(project, args[1])
+
+	if repo == "" {
+		if !tui.IsInteractive(cmd) {
+			clierr.Exit(clierr.Invalidf("pass --repo to convert a draft in non-interactive mode"))
+		}
+		err := huh.NewSelect[string]().
+			Title(fmt.Sprintf("Repository for %q", item.Title)).
+			Options(huh.NewOptions(repoOptions(project.Owner)...)...).
+			Value(&repo).
+			Run()
+		if err != nil {
+			clierr.Exit(err)
+		}
+	}
+	if dryRun {
+		fmt.Printf("Dry run: would convert draft %q to an issue of %s\n", item.Title, repo)
+		return
+	}
+
+	rec := journal.Begin("project item convert")
+	converted, err := views.ConvertDraft(rec, project, item, repo)
+	if converted.URL != "" {
+		fmt.Printf("Converted draft %q to %s\n", item.Title, converted.URL)
+	}
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the conversion was not recorded: %v\n", err)
+	}
+}
+
+// draftBody returns the body given with --body or read from --body-file
+func draftBody(cmd *cobra.Command) string {
+	body, _ := cmd.Flags().GetString("body")
+	if path, _ := cmd.Flags().GetString("body-file"); path != "" {
+		data, err := readInput(path)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		body = strings.TrimRight(data, "\n")
+	}
+	return body
+}
+
+// loadDraft returns the draft issue of project referenced by ref
+func loadDraft(project *models.Project, ref string) models.Item {
+	q, _ := filter.Parse("is:draft")
+	drafts, err := matchingItems(project, q, false)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	item, err := findItem(drafts, ref)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	return item
+}
+
+// repoOptions lists the repositories of owner to convert drafts into, or
+// exits when there are none
+func repoOptions(owner string) []string {
+	repos, err := ghc.GetOwnerRepos(owner)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if len(repos) == 0 {
+		clierr.Exit(clierr.Invalidf("%s has no repositories, pass --repo", owner))
+	}
+	return repos
+}
@@ -0,0 +1,167 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// ItemEditAction handles the 'project item edit' command
+func ItemEditAction(cmd *cobra.Command, args []string) {
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid project number %q", args[0]))
+	}
+	owner, _ := cmd.Flags().GetString("owner")
+	query, _ := cmd.Flags().GetString("filter")
+	sets, _ := cmd.Flags().GetStringArray("set")
+
+	var change views.Change
+	change.AddLabels, _ = cmd.Flags().GetStringSlice("add-label")
+	change.RemoveLabels, _ = cmd.Flags().GetStringSlice("remove-label")
+
+	q, err := filter.Parse(query)
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid filter: %w", err))
+	}
+	if q.Empty() {
+		clierr.Exit(clierr.Invalidf("pass --filter to choose the items to edit"))
+	}
+
+	project, err := ghc.GetProject(owner, number)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	for _, s := range sets {
+		fc, err := parseSet(project, s)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		change.Fields = append(change.Fields, fc)
+	}
+	if change.Empty() {
+		clierr.Exit(clierr.Invalidf("nothing to change, pass --set, --add-label or --remove-label"))
+	}
+
+	items, err := matchingItems(project, q, false)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if len(items) == 0 {
+		fmt.Println("No items match the filter")
+		return
+	}
+	runBulk(cmd, "project item edit", project, items, change)
+}
+
+// matchingItems returns the items of project matching q, the archived ones
+// when archived is set and the others otherwise
+func matchingItems(project *models.Project, q filter.Query, archived bool) ([]models.Item, error) {
+	user, err := ghc.GetWhoami()
+	if err != nil {
+		return nil, err
+	}
+	all, err := ghc.GetItems(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	env := filter.Env{Viewer: user.Login, Project: project}
+	var items []models.Item
+	for _, item := range all {
+		if item.Archived == archived && q.Match(item, env) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// runBulk previews change on items, asks for confirmation unless --yes is
+// passed and applies it on behalf of command, honouring --dry-run
+func runBulk(cmd *cobra.Command, command string, project *models.Project, items []models.Item, change views.Change) {
+	yes, _ := cmd.Flags().GetBool("yes")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if err := views.PrintBulkPreview(os.Stdout, items, change); err != nil {
+		clierr.Exit(err)
+	}
+	fmt.Println()
+	if dryRun {
+		fmt.Printf("Dry run: %d items would be updated\n", len(items))
+		return
+	}
+
+	interactive := tui.IsInteractive(cmd)
+	if !yes {
+		if !interactive {
+			clierr.Exit(clierr.Invalidf("pass --yes to apply changes in non-interactive mode"))
+		}
+		confirmed := false
+		err := huh.NewConfirm().
+			Title(fmt.Sprintf("Apply %s to %d items?", change, len(items))).
+			Value(&confirmed).
+			Run()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled")
+			return
+		}
+	}
+
+	rec := journal.Begin(command)
+	var results []views.BulkResult
+	if interactive {
+		model, err := tea.NewProgram(views.NewBulkEditModel(rec, project.ID, items, change)).Run()
+		if err != nil {
+			clierr.Exit(fmt.Errorf("running program: %w", err))
+		}
+		results = model.(views.BulkEditModel).Results()
+	} else {
+		results = views.ApplyBulk(rec, project.ID, items, change, nil)
+	}
+
+	if err := views.PrintBulkResults(os.Stdout, results); err != nil {
+		clierr.Exit(err)
+	}
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the changes were not recorded and cannot be undone: %v\n", err)
+	}
+	for _, r := range results {
+		if r.Err != nil || r.Skipped || r.InFlight {
+			os.Exit(clierr.ExitError)
+		}
+	}
+}
+
+// parseSet parses a --set Field=Value flag against the project's fields
+func parseSet(project *models.Project, s string) (views.FieldChange, error) {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return views.FieldChange{}, clierr.Invalidf("invalid --set %q, expected Field=Value", s)
+	}
+	name = strings.TrimSpace(name)
+	for _, f := range project.EditableFields() {
+		if strings.EqualFold(f.Name, name) {
+			v, err := f.ParseValue(value, time.Now())
+			if err != nil {
+				return views.FieldChange{}, err
+			}
+			return views.FieldChange{Field: f, Value: v}, nil
+		}
+	}
+	return views.FieldChange{}, clierr.NotFoundf("project %q has no editable field named %q", project.Title, name)
+}
@@ -5,21 +5,35 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/spf13/cobra"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/tui"
 	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
 )
 
 // ListAction handles the 'project list' command
 func ListAction(cmd *cobra.Command, args []string) {
+	// Print a plain table when there is no terminal to draw on
+	if !tui.IsInteractive(cmd) {
+		projects, err := ghc.GetProjects()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		if err := views.PrintProjects(os.Stdout, projects); err != nil {
+			clierr.Exit(err)
+		}
+		return
+	}
+
 	// Create and run the BubbleTea program
 	p := tea.NewProgram(
 		views.NewProjectsListViewModel(),
 		tea.WithAltScreen(),       // Use the full terminal window
 		tea.WithMouseCellMotion(), // Enable mouse support
 	)
-	
+
 	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
-		os.Exit(1)
+		clierr.Exit(fmt.Errorf("running program: %w", err))
 	}
 }
@@ -0,0 +1,29 @@
+package actions
+
+import (
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// CloseAction handles the 'project close' command
+func CloseAction(cmd *cobra.Command, args []string) {
+	project := loadProject(cmd, args)
+	changeSettings(cmd, "project close", project, map[string]string{models.SettingState: "closed"})
+}
+
+// ReopenAction handles the 'project reopen' command
+func ReopenAction(cmd *cobra.Command, args []string) {
+	project := loadProject(cmd, args)
+	changeSettings(cmd, "project reopen", project, map[string]string{models.SettingState: "open"})
+}
+
+// VisibilityAction handles the 'project visibility' command
+func VisibilityAction(cmd *cobra.Command, args []string) {
+	visibility := args[1]
+	if visibility != "public" && visibility != "private" {
+		clierr.Exit(clierr.Invalidf("invalid visibility %q, expected public or private", visibility))
+	}
+	project := loadProject(cmd, args)
+	changeSettings(cmd, "project visibility", project, map[string]string{models.SettingVisibility: visibility})
+}
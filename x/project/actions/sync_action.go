@@ -0,0 +1,105 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/config"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// SyncAction handles the 'project sync' command
+func SyncAction(cmd *cobra.Command, args []string) {
+	owner, _ := cmd.Flags().GetString("owner")
+
+	var numbers []int
+	for _, arg := range args {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			clierr.Exit(clierr.Invalidf("invalid project number %q", arg))
+		}
+		numbers = append(numbers, n)
+	}
+	workers, err := syncWorkers(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+
+	user, err := ghc.GetWhoami()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if owner == "" || owner == "@me" {
+		owner = user.Login
+	}
+	s, err := store.Open()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	defer s.Close()
+	if err := s.SetMeta(store.MetaViewer, user.Login); err != nil {
+		clierr.Exit(err)
+	}
+
+	// Projects download concurrently and are saved one at a time as they
+	// arrive; ctrl+c stops the requests in flight
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	results := make(chan ghc.ProjectResult)
+	done := func(r ghc.ProjectResult) {
+		select {
+		case results <- r:
+		case <-ctx.Done():
+		}
+	}
+	pool := ghc.NewPool(ctx, workers)
+	if len(numbers) == 0 {
+		ghc.FetchProjects(pool, []string{owner}, true, done)
+	}
+	for _, n := range numbers {
+		pool.Go(func(ctx context.Context) {
+			done(ghc.FetchProject(ctx, owner, n))
+		})
+	}
+	go func() {
+		pool.Wait()
+		close(results)
+	}()
+
+	failed := 0
+	for r := range results {
+		if r.Err == nil {
+			r.Err = s.SaveProject(r.Project, r.Items)
+		}
+		if r.Err != nil {
+			clierr.Print(os.Stderr, r.Err)
+			failed++
+			continue
+		}
+		fmt.Printf("Synced %s #%d %s (%d items)\n", r.Project.Owner, r.Project.Number, r.Project.Title, len(r.Items))
+	}
+	if err := ctx.Err(); err != nil {
+		clierr.Exit(err)
+	}
+	if failed > 0 {
+		os.Exit(clierr.ExitError)
+	}
+}
+
+// syncWorkers returns the --workers flag, falling back to the configuration
+func syncWorkers(cmd *cobra.Command) (int, error) {
+	if cmd.Flags().Changed("workers") {
+		return cmd.Flags().GetInt("workers")
+	}
+	c, err := config.Load()
+	if err != nil {
+		return 0, err
+	}
+	return c.Concurrency(), nil
+}
@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+// ViewAction handles the 'project view' command
+func ViewAction(cmd *cobra.Command, args []string) {
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid project number %q", args[0]))
+	}
+	owner, _ := cmd.Flags().GetString("owner")
+
+	var opts views.ViewOptions
+	opts.Layout, _ = cmd.Flags().GetString("layout")
+	opts.Columns, _ = cmd.Flags().GetStringSlice("columns")
+	opts.Sort, _ = cmd.Flags().GetString("sort")
+	opts.Group, _ = cmd.Flags().GetString("group")
+	opts.Filter, _ = cmd.Flags().GetString("filter")
+	opts.Cached, _ = cmd.Flags().GetBool("cached")
+	opts.Archived, _ = cmd.Flags().GetBool("archived")
+	RunView(cmd, owner, number, opts)
+}
+
+// RunView opens a project with the given layout options, printing a plain
+// table instead when the command is not interactive
+func RunView(cmd *cobra.Command, owner string, number int, opts views.ViewOptions) {
+	query, err := filter.Parse(opts.Filter)
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid filter: %w", err))
+	}
+
+	// Print a plain table when there is no terminal to draw on
+	if !tui.IsInteractive(cmd) && opts.Cached {
+		// The cache evaluates the filter in the database
+		project, items, viewer, err := views.LoadCached(owner, number, query)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		opts.Filter = ""
+		if err := views.PrintItems(os.Stdout, project, items, opts, viewer); err != nil {
+			clierr.Exit(err)
+		}
+		return
+	}
+	if !tui.IsInteractive(cmd) {
+		project, err := ghc.GetProject(owner, number)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		user, err := ghc.GetWhoami()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		items, err := ghc.GetItems(project.ID)
+		if err != nil {
+			clierr.Exit(err)
+		}
+		if err := views.PrintItems(os.Stdout, project, items, opts, user.Login); err != nil {
+			clierr.Exit(err)
+		}
+		return
+	}
+
+	runProjectView(views.NewProjectViewModel(owner, number, opts))
+}
+
+// RunLoadedView opens a project whose items were already fetched in the
+// terminal, so that they are not fetched again
+func RunLoadedView(project *models.Project, items []models.Item, viewer string, opts views.ViewOptions) {
+	runProjectView(views.NewLoadedProjectViewModel(project, items, viewer, opts))
+}
+
+func runProjectView(m views.ProjectViewModel) {
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	if _, err := p.Run(); err != nil {
+		clierr.Exit(fmt.Errorf("running program: %w", err))
+	}
+}
@@ -1,29 +1,403 @@
 package project
 
 import (
+	"github.com/prnk28/gh-pm/internal/complete"
+	"github.com/prnk28/gh-pm/internal/ctx"
 	"github.com/prnk28/gh-pm/x/project/actions"
 	"github.com/spf13/cobra"
 )
 
 func Command() *cobra.Command {
 	// Define the subcommands
-	subCommands := []*cobra.Command{
-		{
-			Use:   "create",
-			Short: "Create a project",
-			Run:   actions.CreateAction,
+	createCmd := &cobra.Command{
+		Use:         "create",
+		Short:       "Create a project",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Run:         actions.CreateAction,
+	}
+	createCmd.Flags().String("title", "", "Title of the new project")
+	createCmd.Flags().String("owner", "", "Organization that owns the project (defaults to you)")
+	createCmd.Flags().String("description", "", "Short description of the project")
+	createCmd.Flags().Int("template-project", 0, "Number of a project whose fields, views and workflows are copied")
+	createCmd.Flags().String("template-owner", "", "Login of the owner of the template project (defaults to you)")
+	createCmd.Flags().Bool("drafts", false, "Copy the draft issues of the template project too")
+	createCmd.Flags().String("template-file", "", "YAML file written by 'project export' to start from")
+	createCmd.Flags().Bool("dry-run", false, "Show the project that would be created without creating it")
+
+	viewCmd := &cobra.Command{
+		Use:               "view <number>",
+		Short:             "View the items of a project as a board or a table",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.ViewAction,
+	}
+	viewCmd.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	viewCmd.Flags().String("layout", "board", "Layout to open: board or table")
+	viewCmd.Flags().StringSlice("columns", nil, "Table columns to show, e.g. Title,Status,Priority")
+	viewCmd.Flags().String("sort", "", "Column to sort by, append :desc for descending order")
+	viewCmd.Flags().String("group", "", "Column to group table rows by")
+	viewCmd.Flags().String("filter", "", `Only show items matching a query, e.g. 'status:"In Progress" assignee:@me -label:bug updated:>7d'`)
+	viewCmd.Flags().Bool("cached", false, "Read the project from the local cache filled by 'project sync'")
+	viewCmd.Flags().Bool("archived", false, "Show the archived items instead of the others")
+
+	syncCmd := &cobra.Command{
+		Use:   "sync [number...]",
+		Short: "Download projects and their items into the local cache",
+		Long: `Download projects and their items into the local cache used by --cached,
+reports and shell completions. Without numbers every project of the owner is synced.
+Each sync records the field values that changed since the previous one, so the
+history used by 'gh pm report' grows with every run.`,
+		ValidArgsFunction: complete.Projects,
+		Run:               actions.SyncAction,
+	}
+	syncCmd.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	syncCmd.Flags().Int("workers", 0, "Number of projects downloaded at the same time (default 4, or the workers setting)")
+
+	exportCmd := &cobra.Command{
+		Use:   "export <number>",
+		Short: "Print the definition of a project as YAML",
+		Long: `Print the title, description, readme, fields and views of a project as YAML,
+to be versioned and applied with 'gh pm project apply'.`,
+		Example:           `  gh pm project export 3 > project.yaml`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.ExportAction,
+	}
+	exportCmd.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+
+	applyCmd := &cobra.Command{
+		Use:         "apply <file>",
+		Short:       "Create or update a project to match a YAML definition",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Create or update a project to match a YAML definition written by
+'gh pm project export'. The changes are listed as a plan and applied after
+confirmation. The project of the file is updated unless --create is passed;
+--owner and --number point the file at another project.
+
+Views cannot be created or changed through the API, so differences in views
+are only reported. Fields missing from the file are kept unless --prune is passed.`,
+		Example: `  gh pm project apply project.yaml --dry-run
+  gh pm project apply project.yaml --owner my-new-team --create`,
+		Args: cobra.ExactArgs(1),
+		Run:  actions.ApplyAction,
+	}
+	applyCmd.Flags().String("owner", "", "Login of the project owner (defaults to the owner in the file)")
+	applyCmd.Flags().Int("number", 0, "Number of the project to update (defaults to the number in the file)")
+	applyCmd.Flags().Bool("create", false, "Create a new project instead of updating one")
+	applyCmd.Flags().Bool("prune", false, "Delete fields and options missing from the file")
+	applyCmd.Flags().Bool("dry-run", false, "Show the plan without applying it")
+	applyCmd.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
+
+	editCmd := &cobra.Command{
+		Use:         "edit <number>",
+		Short:       "Change the title, short description or readme of a project",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Change the title, short description or readme of a project. Without flags the
+three are opened in the editor set by GH_EDITOR, VISUAL or EDITOR.`,
+		Example: `  gh pm project edit 3
+  gh pm project edit 3 --title "Roadmap 2025" --readme-file README.md`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.EditAction,
+	}
+	editCmd.Flags().String("title", "", "New title of the project")
+	editCmd.Flags().String("description", "", "New short description of the project")
+	editCmd.Flags().String("readme-file", "", `File holding the new readme, or "-" for standard input`)
+	editCmd.Flags().Bool("dry-run", false, "List the changes without applying them")
+
+	closeCmd := &cobra.Command{
+		Use:               "close <number>",
+		Short:             "Close a project",
+		Annotations:       map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.CloseAction,
+	}
+	closeCmd.Flags().Bool("dry-run", false, "Show the change without applying it")
+
+	reopenCmd := &cobra.Command{
+		Use:               "reopen <number>",
+		Short:             "Reopen a closed project",
+		Annotations:       map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.ReopenAction,
+	}
+	reopenCmd.Flags().Bool("dry-run", false, "Show the change without applying it")
+
+	visibilityCmd := &cobra.Command{
+		Use:         "visibility <number> <public|private>",
+		Short:       "Make a project public or private",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Args:        cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return []string{"public", "private"}, cobra.ShellCompDirectiveNoFileComp
+			}
+			return complete.ProjectArg(cmd, args, toComplete)
 		},
+		Run: actions.VisibilityAction,
+	}
+	visibilityCmd.Flags().Bool("dry-run", false, "Show the change without applying it")
+
+	deleteCmd := &cobra.Command{
+		Use:         "delete <number>",
+		Short:       "Delete a project and its items",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Delete a project and its items. The title of the project has to be typed to
+confirm, unless --yes is passed. Deleted projects cannot be restored.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.DeleteAction,
+	}
+	deleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+	deleteCmd.Flags().Bool("dry-run", false, "Show the project that would be deleted without deleting it")
+
+	for _, c := range []*cobra.Command{editCmd, closeCmd, reopenCmd, visibilityCmd, deleteCmd} {
+		c.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	}
+
+	fieldListCmd := &cobra.Command{
+		Use:               "list <number>",
+		Short:             "List the fields of a project",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.FieldListAction,
+	}
+
+	fieldCreateCmd := &cobra.Command{
+		Use:         "create <number>",
+		Short:       "Add a custom field to a project",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Add a text, number, date, single select or iteration field to a project.
+Options of single select fields are written as name[:color[:description]], with
+colors gray, blue, green, yellow, orange, red, pink or purple.`,
+		Example: `  gh pm project field create 3 --name Size --type single_select --option S:green --option M:yellow --option L:red
+  gh pm project field create 3 --name Sprint --type iteration --duration 14 --start-day monday`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.FieldCreateAction,
+	}
+	fieldCreateCmd.Flags().String("name", "", "Name of the field")
+	fieldCreateCmd.Flags().String("type", "text", "Type of the field: text, number, date, single_select or iteration")
+	fieldCreateCmd.Flags().StringArray("option", nil, "Option of a single select field as name[:color[:description]] (repeatable)")
+	fieldCreateCmd.Flags().Int("duration", 14, "Length of iterations in days")
+	fieldCreateCmd.Flags().String("start-day", "monday", "Weekday iterations start on")
+	fieldCreateCmd.Flags().Bool("dry-run", false, "Show the field without creating it")
+
+	fieldEditCmd := &cobra.Command{
+		Use:         "edit <number> <field>",
+		Short:       "Rename a field or change the options of a single select field",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Rename a field or change the options of a single select field. --option adds
+an option or changes the color and description of an existing one. Options
+keep their values on items unless they are removed.`,
+		Example: `  gh pm project field edit 3 Status --option Blocked:red:"Waiting on someone" --order Todo,Blocked
+  gh pm project field edit 3 Priority --rename-option Urgent=P0 --remove-option Someday`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: complete.ProjectThenField,
+		Run:               actions.FieldEditAction,
+	}
+	fieldEditCmd.Flags().String("name", "", "New name of the field")
+	fieldEditCmd.Flags().StringArray("option", nil, "Add or update an option as name[:color[:description]] (repeatable)")
+	fieldEditCmd.Flags().StringArray("rename-option", nil, "Rename an option as Old=New (repeatable)")
+	fieldEditCmd.Flags().StringSlice("remove-option", nil, "Options to remove, clearing them from items")
+	fieldEditCmd.Flags().StringSlice("order", nil, "Options to list first, in this order")
+	fieldEditCmd.Flags().BoolP("yes", "y", false, "Remove options without asking for confirmation")
+	fieldEditCmd.Flags().Bool("dry-run", false, "List the changes without applying them")
+
+	fieldDeleteCmd := &cobra.Command{
+		Use:               "delete <number> <field>",
+		Short:             "Delete a custom field and its values",
+		Annotations:       map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: complete.ProjectThenField,
+		Run:               actions.FieldDeleteAction,
+	}
+	fieldDeleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+	fieldDeleteCmd.Flags().Bool("dry-run", false, "Show the field that would be deleted without deleting it")
+
+	fieldCmd := &cobra.Command{
+		Use:   "field",
+		Short: "Manage the fields of a project",
+	}
+	fieldCmd.PersistentFlags().String("owner", "", "Login of the project owner (defaults to you)")
+	fieldCmd.AddCommand(fieldListCmd, fieldCreateCmd, fieldEditCmd, fieldDeleteCmd)
+
+	itemEditCmd := &cobra.Command{
+		Use:         "edit <number>",
+		Short:       "Edit the items of a project matching a filter",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Edit every item of a project matching a filter. The affected items are listed
+and the change is applied after confirmation.`,
+		Example: `  gh pm project item edit 3 --filter 'label:bug no:priority' --set Priority=P2
+  gh pm project item edit 3 --filter 'status:Review is:merged' --set Status=Done --add-label shipped`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.ItemEditAction,
+	}
+	itemEditCmd.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	itemEditCmd.Flags().String("filter", "", "Query choosing the items to edit")
+	itemEditCmd.Flags().StringArray("set", nil, "Set a field, e.g. Status=Done; an empty value clears it (repeatable)")
+	itemEditCmd.Flags().StringSlice("add-label", nil, "Labels to add to the issues and pull requests")
+	itemEditCmd.Flags().StringSlice("remove-label", nil, "Labels to remove from the issues and pull requests")
+	itemEditCmd.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
+	itemEditCmd.Flags().Bool("dry-run", false, "List the changes without applying them")
+
+	itemArchiveCmd := &cobra.Command{
+		Use:         "archive <number> [item...]",
+		Short:       "Archive items of a project",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Archive items of a project, given as owner/repo#12, URLs or draft titles, or
+every item matching --filter. Archived items are hidden from the views of the project
+and can be restored with 'gh pm project item unarchive'.`,
+		Example: `  gh pm project item archive 3 acme/api#12 acme/api#15
+  gh pm project item archive 3 --filter 'status:Done is:closed'`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: complete.ProjectThenItems,
+		Run:               actions.ItemArchiveAction,
+	}
+
+	itemUnarchiveCmd := &cobra.Command{
+		Use:         "unarchive <number> [item...]",
+		Short:       "Restore archived items of a project",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Restore archived items of a project, given as owner/repo#12, URLs or draft
+titles, or every archived item matching --filter.`,
+		Example:           `  gh pm project item unarchive 3 --filter 'label:regression'`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: complete.ProjectThenItems,
+		Run:               actions.ItemUnarchiveAction,
+	}
+
+	for _, c := range []*cobra.Command{itemArchiveCmd, itemUnarchiveCmd} {
+		c.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+		c.Flags().String("filter", "", "Query choosing the items")
+		c.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
+		c.Flags().Bool("dry-run", false, "List the items without changing them")
+	}
+
+	itemAddCmd := &cobra.Command{
+		Use:         "add <number> [url...]",
+		Short:       "Add issues and pull requests to a project",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Add issues and pull requests to a project, given by URL or found with a GitHub
+search query. Items already in the project are skipped. Search results are
+listed and added after confirmation.`,
+		Example: `  gh pm project item add 3 https://github.com/acme/api/issues/12
+  gh pm project item add 3 --search "repo:acme/api is:open label:bug"`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.ItemAddAction,
+	}
+	itemAddCmd.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	itemAddCmd.Flags().String("search", "", "GitHub search query for the issues and pull requests to add")
+	itemAddCmd.Flags().Int("limit", 100, "Maximum number of search results to add")
+	itemAddCmd.Flags().BoolP("yes", "y", false, "Add search results without asking for confirmation")
+	itemAddCmd.Flags().Bool("dry-run", false, "List the items without adding them")
+
+	itemAddDraftCmd := &cobra.Command{
+		Use:         "add-draft <number>",
+		Short:       "Add a draft issue to a project",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Example: `  gh pm project item add-draft 3 --title "Investigate flaky login test" --set Status=Todo
+  gh pm project item add-draft 3 --title "Release notes" --body-file notes.md`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.ItemAddDraftAction,
+	}
+	itemAddDraftCmd.Flags().String("title", "", "Title of the draft")
+	itemAddDraftCmd.Flags().StringArray("set", nil, "Set a field, e.g. Status=Todo (repeatable)")
+	itemAddDraftCmd.Flags().Bool("dry-run", false, "Show the draft without adding it")
+
+	itemEditDraftCmd := &cobra.Command{
+		Use:               "edit-draft <number> <draft>",
+		Short:             "Change the title or body of a draft issue",
+		Annotations:       map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long:              `Change the title or body of a draft issue, given by its title or item ID.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: complete.ProjectThenDraft,
+		Run:               actions.ItemEditDraftAction,
+	}
+	itemEditDraftCmd.Flags().String("title", "", "New title of the draft")
+	itemEditDraftCmd.Flags().Bool("dry-run", false, "Show the change without applying it")
+
+	for _, c := range []*cobra.Command{itemAddDraftCmd, itemEditDraftCmd} {
+		c.Flags().String("body", "", "Body of the draft in Markdown")
+		c.Flags().String("body-file", "", `File holding the body, or "-" for standard input`)
+	}
+
+	itemConvertCmd := &cobra.Command{
+		Use:         "convert <number> <draft>",
+		Short:       "Convert a draft issue into an issue of a repository",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Convert a draft issue, given by its title or item ID, into an issue of a
+repository. The item keeps its place and field values in the project.`,
+		Example:           `  gh pm project item convert 3 "Investigate flaky login test" --repo acme/api`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: complete.ProjectThenDraft,
+		Run:               actions.ItemConvertAction,
+	}
+	itemConvertCmd.Flags().String("repo", "", "Repository to create the issue in, as owner/name")
+	itemConvertCmd.Flags().Bool("dry-run", false, "Show the conversion without applying it")
+
+	for _, c := range []*cobra.Command{itemAddDraftCmd, itemEditDraftCmd, itemConvertCmd} {
+		c.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	}
+
+	itemCmd := &cobra.Command{
+		Use:   "item",
+		Short: "Manage the items of a project",
+	}
+	itemCmd.AddCommand(itemEditCmd, itemAddCmd, itemAddDraftCmd, itemEditDraftCmd, itemConvertCmd, itemArchiveCmd, itemUnarchiveCmd)
+
+	autoArchiveCmd := &cobra.Command{
+		Use:         "autoarchive <number>",
+		Short:       "Archive items that stayed in a status for too long",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Long: `Archive the items of a project in a status, Done by default, that were not
+updated for longer than --older-than. Meant to run on a schedule with --yes.`,
+		Example: `  gh pm project autoarchive 3 --status Done --older-than 14d --dry-run
+  gh pm project autoarchive 3 --older-than 2w --filter 'is:closed' --yes`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: complete.ProjectArg,
+		Run:               actions.AutoArchiveAction,
+	}
+	autoArchiveCmd.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	autoArchiveCmd.Flags().String("status", "Done", "Status of the items to archive")
+	autoArchiveCmd.Flags().String("older-than", "14d", "Archive items not updated for this long, e.g. 14d or 2w")
+	autoArchiveCmd.Flags().String("filter", "", "Query further restricting the items to archive")
+	autoArchiveCmd.Flags().BoolP("yes", "y", false, "Archive without asking for confirmation")
+	autoArchiveCmd.Flags().Bool("dry-run", false, "List the items without archiving them")
+
+	subCommands := []*cobra.Command{
+		createCmd,
 		{
 			Use:   "list",
 			Short: "List all projects",
 			Run:   actions.ListAction,
 		},
+		viewCmd,
+		syncCmd,
+		exportCmd,
+		applyCmd,
+		editCmd,
+		closeCmd,
+		reopenCmd,
+		visibilityCmd,
+		deleteCmd,
+		fieldCmd,
+		itemCmd,
+		autoArchiveCmd,
 	}
 
 	// Create the root command
 	cmd := &cobra.Command{
 		Use:   "project",
 		Short: "Manage projects",
+		// Reading is enough to start; changes made without the project scope
+		// fail with a hint to add it
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeReadProject},
 		Run: func(cmd *cobra.Command, args []string) {
 			actions.ListAction(cmd, args)
 		},
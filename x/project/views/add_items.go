@@ -0,0 +1,45 @@
+package views
+
+import (
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// AddItems adds issues and pull requests, as returned by ghc.GetContent or
+// ghc.SearchContent, to a project with a bounded number of concurrent
+// requests, recording each in rec and calling done as each finishes. Results
+// hold the new items and keep the order of contents.
+func AddItems(rec *journal.Recorder, projectID string, contents []models.Item, done func(BulkResult)) []BulkResult {
+	return forEachBounded(contents, func(content models.Item) BulkResult {
+		item, err := ghc.AddItem(projectID, content.ContentID)
+		if err != nil {
+			return BulkResult{Item: content, Err: err}
+		}
+		rec.ItemAdded(projectID, item)
+		return BulkResult{Item: item}
+	}, done)
+}
+
+// SplitPresent separates contents already on the project, going by items,
+// from the ones still to add
+func SplitPresent(items, contents []models.Item) (missing, present []models.Item) {
+	onProject := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item.ContentID != "" {
+			onProject[item.ContentID] = true
+		}
+	}
+	seen := map[string]bool{}
+	for _, c := range contents {
+		switch {
+		case seen[c.ContentID]:
+		case onProject[c.ContentID]:
+			present = append(present, c)
+		default:
+			missing = append(missing, c)
+		}
+		seen[c.ContentID] = true
+	}
+	return missing, present
+}
@@ -0,0 +1,211 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+const (
+	boardMinColumnWidth = 28
+	boardCardHeight     = 4
+	noStatusColumn      = "No Status"
+)
+
+var (
+	boardColumnTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#4F5D75")).Padding(0, 1)
+	boardCardStyle        = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240")).Padding(0, 1)
+	boardSelectedStyle    = boardCardStyle.BorderForeground(lipgloss.Color("205"))
+	boardRefStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	boardMarkedStyle      = boardCardStyle.BorderForeground(lipgloss.Color("33"))
+)
+
+// markPrefix is shown before the title of items marked for a bulk edit
+const markPrefix = "● "
+
+// Message sent when a card is chosen on the board
+type openItemMsg struct {
+	itemID string
+}
+
+type boardColumn struct {
+	name  string
+	items []models.Item
+}
+
+// BoardModel renders project items as columns grouped by their Status
+type BoardModel struct {
+	columns []boardColumn
+	marked  map[string]bool
+	col     int
+	row     int
+	width   int
+	height  int
+}
+
+// NewBoardModel creates an empty board
+func NewBoardModel() BoardModel {
+	return BoardModel{}
+}
+
+// SetSize sets the area available to the board
+func (b *BoardModel) SetSize(width, height int) {
+	b.width = width
+	b.height = height
+}
+
+// SetItems groups items into columns by the options of the project's Status field,
+// keeping the current card selected when it is still present
+func (b *BoardModel) SetItems(project *models.Project, items []models.Item) {
+	selected, hasSelection := b.Selected()
+	column := b.SelectedColumn()
+
+	columns := []boardColumn{{name: noStatusColumn}}
+	if status := project.Field(models.StatusField); status != nil {
+		for _, o := range status.Options {
+			columns = append(columns, boardColumn{name: o.Name})
+		}
+	}
+	for _, item := range items {
+		idx := 0
+		for i, c := range columns {
+			if c.name == item.Status() {
+				idx = i
+				break
+			}
+		}
+		columns[idx].items = append(columns[idx].items, item)
+	}
+	// Hide the "No Status" column when every item has a status
+	if len(columns) > 1 && len(columns[0].items) == 0 {
+		columns = columns[1:]
+	}
+	b.columns = columns
+
+	b.col, b.row = 0, 0
+	// An empty column stays selected by name
+	for ci, c := range b.columns {
+		if c.name == column && column != "" {
+			b.col = ci
+		}
+	}
+	if hasSelection {
+		for ci, c := range b.columns {
+			for ri, item := range c.items {
+				if item.ID == selected.ID {
+					b.col, b.row = ci, ri
+				}
+			}
+		}
+	}
+}
+
+// SetMarked sets the items marked for a bulk edit
+func (b *BoardModel) SetMarked(marked map[string]bool) {
+	b.marked = marked
+}
+
+// Selected returns the card under the cursor
+func (b BoardModel) Selected() (models.Item, bool) {
+	if b.col >= len(b.columns) || b.row >= len(b.columns[b.col].items) {
+		return models.Item{}, false
+	}
+	return b.columns[b.col].items[b.row], true
+}
+
+// SelectedColumn returns the status of the column under the cursor, or ""
+// for the column of items without a status
+func (b BoardModel) SelectedColumn() string {
+	if b.col >= len(b.columns) || b.columns[b.col].name == noStatusColumn {
+		return ""
+	}
+	return b.columns[b.col].name
+}
+
+// Update moves the cursor between columns and cards
+func (b BoardModel) Update(msg tea.Msg) (BoardModel, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok || len(b.columns) == 0 {
+		return b, nil
+	}
+	switch key.String() {
+	case "left", "h":
+		if b.col > 0 {
+			b.col--
+		}
+	case "right", "l":
+		if b.col < len(b.columns)-1 {
+			b.col++
+		}
+	case "up", "k":
+		if b.row > 0 {
+			b.row--
+		}
+	case "down", "j":
+		b.row++
+	case "home", "g":
+		b.row = 0
+	case "end", "G":
+		b.row = len(b.columns[b.col].items) - 1
+	case "enter":
+		if item, ok := b.Selected(); ok {
+			return b, func() tea.Msg { return openItemMsg{itemID: item.ID} }
+		}
+	}
+	// Keep the row inside the current column
+	if n := len(b.columns[b.col].items); b.row >= n {
+		b.row = max(n-1, 0)
+	}
+	return b, nil
+}
+
+// View renders the columns that fit the width, scrolled to keep the cursor visible
+func (b BoardModel) View() string {
+	if len(b.columns) == 0 {
+		return "No items in this project"
+	}
+
+	visible := max(b.width/boardMinColumnWidth, 1)
+	visible = min(visible, len(b.columns))
+	colWidth := b.width / visible
+	first := min(max(b.col-visible+1, 0), len(b.columns)-visible)
+
+	rendered := make([]string, 0, visible)
+	for ci := first; ci < first+visible; ci++ {
+		rendered = append(rendered, b.renderColumn(ci, colWidth))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+func (b BoardModel) renderColumn(ci, width int) string {
+	c := b.columns[ci]
+	cardWidth := max(width-4, 10)
+	lines := []string{
+		boardColumnTitleStyle.Render(text.Truncate(width-3, fmt.Sprintf("%s (%d)", c.name, len(c.items)))),
+	}
+
+	fits := max((b.height-1)/boardCardHeight, 1)
+	offset := 0
+	if ci == b.col && b.row >= fits {
+		offset = b.row - fits + 1
+	}
+	for ri := offset; ri < len(c.items) && ri < offset+fits; ri++ {
+		item := c.items[ri]
+		style := boardCardStyle
+		title := item.Title
+		if b.marked[item.ID] {
+			style = boardMarkedStyle
+			title = markPrefix + title
+		}
+		if ci == b.col && ri == b.row {
+			style = boardSelectedStyle
+		}
+		card := text.Truncate(cardWidth-2, title) + "\n" + boardRefStyle.Render(text.Truncate(cardWidth-2, item.Ref()))
+		lines = append(lines, style.Width(cardWidth).Render(card))
+	}
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
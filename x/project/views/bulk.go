@@ -0,0 +1,418 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnk28/gh-pm/internal/config"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+// defaultBulkWorkers bounds the number of items updated at the same time when
+// the workers setting is not set
+const defaultBulkWorkers = 4
+
+// bulkWorkers returns the number of items updated at the same time
+func bulkWorkers() int {
+	if c, err := config.Load(); err == nil && c.Concurrency() > 0 {
+		return c.Concurrency()
+	}
+	return defaultBulkWorkers
+}
+
+// Change is an edit applied to many items at once
+type Change struct {
+	Fields       []FieldChange
+	AddLabels    []string
+	RemoveLabels []string
+	// Archive archives the items when true and restores them when false
+	Archive *bool
+}
+
+// FieldChange sets a field to a value; a zero value clears the field
+type FieldChange struct {
+	Field models.Field
+	Value models.FieldValue
+}
+
+// Empty reports whether the change does nothing
+func (c Change) Empty() bool {
+	return len(c.Fields) == 0 && len(c.AddLabels) == 0 && len(c.RemoveLabels) == 0 && c.Archive == nil
+}
+
+// String describes the change, e.g. "Status=Done, +bug, -triage"
+func (c Change) String() string {
+	var parts []string
+	for _, f := range c.Fields {
+		parts = append(parts, f.Field.Name+"="+f.Value.Text)
+	}
+	for _, l := range c.AddLabels {
+		parts = append(parts, "+"+l)
+	}
+	for _, l := range c.RemoveLabels {
+		parts = append(parts, "-"+l)
+	}
+	if c.Archive != nil {
+		parts = append(parts, archiveVerb(*c.Archive))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Apply makes the change on one item through the API, recording what it
+// changed in rec
+func (c Change) Apply(rec *journal.Recorder, projectID string, item models.Item) error {
+	for _, f := range c.Fields {
+		if err := ghc.SetItemField(projectID, item.ID, f.Field, f.Value); err != nil {
+			return fmt.Errorf("%s: %w", f.Field.Name, err)
+		}
+		rec.Field(projectID, item, f.Field, item.Values[f.Field.Name], f.Value)
+	}
+	if len(c.AddLabels) > 0 || len(c.RemoveLabels) > 0 {
+		if err := ghc.EditLabels(item, c.AddLabels, c.RemoveLabels); err != nil {
+			return err
+		}
+		rec.Labels(projectID, item, c.AddLabels, c.RemoveLabels)
+	}
+	if c.Archive != nil && item.Archived != *c.Archive {
+		if err := ghc.SetItemArchived(projectID, item.ID, *c.Archive); err != nil {
+			return err
+		}
+		rec.Archived(projectID, item, *c.Archive)
+	}
+	return nil
+}
+
+// archiveVerb names the change of an item's archived state
+func archiveVerb(archive bool) string {
+	if archive {
+		return "archive"
+	}
+	return "unarchive"
+}
+
+// archivedState describes whether an item is archived
+func archivedState(archived bool) string {
+	if archived {
+		return "archived"
+	}
+	return "active"
+}
+
+// ApplyTo returns item as it looks after the change
+func (c Change) ApplyTo(item models.Item) models.Item {
+	item.Values = cloneValues(item.Values)
+	for _, f := range c.Fields {
+		item.SetValue(f.Field.Name, f.Value)
+	}
+	if item.Type != models.ItemTypeDraftIssue {
+		labels := slices.DeleteFunc(slices.Clone(item.Labels), func(l string) bool {
+			return slices.Contains(c.RemoveLabels, l)
+		})
+		for _, l := range c.AddLabels {
+			if !slices.Contains(labels, l) {
+				labels = append(labels, l)
+			}
+		}
+		item.Labels = labels
+	}
+	if c.Archive != nil {
+		item.Archived = *c.Archive
+	}
+	return item
+}
+
+func cloneValues(values map[string]models.FieldValue) map[string]models.FieldValue {
+	out := make(map[string]models.FieldValue, len(values))
+	for k, v := range values {
+		out[k] = v
+	}
+	return out
+}
+
+// BulkResult is the outcome of a bulk edit for one item
+type BulkResult struct {
+	Item models.Item
+	Err  error
+	// Skipped is set when the edit was cancelled before reaching the item
+	Skipped bool
+	// InFlight is set when the edit was cancelled while the request for the
+	// item was pending, so it may or may not have been applied
+	InFlight bool
+}
+
+// ApplyBulk applies change to items with a bounded number of concurrent
+// requests, calling done as each item finishes. Results keep the item order.
+func ApplyBulk(rec *journal.Recorder, projectID string, items []models.Item, change Change, done func(BulkResult)) []BulkResult {
+	return forEachBounded(items, func(item models.Item) BulkResult {
+		return BulkResult{Item: item, Err: change.Apply(rec, projectID, item)}
+	}, done)
+}
+
+// forEachBounded runs fn on every item with at most bulkWorkers() at the same
+// time, calling done as each item finishes. Results keep the item order.
+func forEachBounded(items []models.Item, fn func(models.Item) BulkResult, done func(BulkResult)) []BulkResult {
+	results := make([]BulkResult, len(items))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, bulkWorkers())
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r := fn(item)
+			mu.Lock()
+			results[i] = r
+			if done != nil {
+				done(r)
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// bulkItemDoneMsg reports that the item at index of a bulk run finished
+type bulkItemDoneMsg struct {
+	run   *bulkRun
+	index int
+	err   error
+}
+
+// bulkRun applies a change to items from a Bubble Tea program, keeping at
+// most workers commands in flight
+type bulkRun struct {
+	rec       *journal.Recorder
+	workers   int
+	projectID string
+	change    Change
+	items     []models.Item
+	results   []BulkResult
+	next      int
+	finished  int
+	stopped   bool
+	progress  progress.Model
+}
+
+func newBulkRun(rec *journal.Recorder, projectID string, items []models.Item, change Change) *bulkRun {
+	results := make([]BulkResult, len(items))
+	for i, item := range items {
+		results[i] = BulkResult{Item: item, Skipped: true}
+	}
+	return &bulkRun{
+		rec:       rec,
+		workers:   bulkWorkers(),
+		projectID: projectID,
+		change:    change,
+		items:     items,
+		results:   results,
+		progress:  progress.New(progress.WithDefaultGradient()),
+	}
+}
+
+// Start dispatches the first batch of items
+func (r *bulkRun) Start() tea.Cmd {
+	var cmds []tea.Cmd
+	for len(cmds) < r.workers && r.next < len(r.items) {
+		cmds = append(cmds, r.dispatch())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (r *bulkRun) dispatch() tea.Cmd {
+	i := r.next
+	r.next++
+	r.results[i] = BulkResult{Item: r.items[i], InFlight: true}
+	rec, item, change, projectID := r.rec, r.items[i], r.change, r.projectID
+	return func() tea.Msg {
+		return bulkItemDoneMsg{run: r, index: i, err: change.Apply(rec, projectID, item)}
+	}
+}
+
+// Handle records a finished item and dispatches the next one
+func (r *bulkRun) Handle(msg bulkItemDoneMsg) tea.Cmd {
+	r.results[msg.index] = BulkResult{Item: r.items[msg.index], Err: msg.err}
+	r.finished++
+	if !r.stopped && r.next < len(r.items) {
+		return r.dispatch()
+	}
+	return nil
+}
+
+// Stop dispatches no more items; the run is done once the requests in flight
+// finish
+func (r *bulkRun) Stop() {
+	r.stopped = true
+}
+
+// Done reports whether every item has finished, or every dispatched item once
+// the run is stopped
+func (r *bulkRun) Done() bool {
+	return r.finished == len(r.items) || (r.stopped && r.finished == r.next)
+}
+
+// Failed counts the items whose update failed
+func (r *bulkRun) Failed() int {
+	n := 0
+	for _, res := range r.results {
+		if res.Err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// View renders a progress bar with the number of finished items
+func (r *bulkRun) View(width int) string {
+	r.progress.Width = max(min(width-20, 60), 10)
+	return fmt.Sprintf("%s %d/%d", r.progress.ViewAs(float64(r.finished)/float64(max(len(r.items), 1))), r.finished, len(r.items))
+}
+
+// BulkEditModel runs a bulk edit in its own program, showing its progress
+type BulkEditModel struct {
+	run   *bulkRun
+	width int
+}
+
+// NewBulkEditModel creates a program applying change to items, recording the
+// changes in rec
+func NewBulkEditModel(rec *journal.Recorder, projectID string, items []models.Item, change Change) BulkEditModel {
+	return BulkEditModel{run: newBulkRun(rec, projectID, items, change), width: 80}
+}
+
+// Init starts the first requests
+func (m BulkEditModel) Init() tea.Cmd {
+	return m.run.Start()
+}
+
+// Update records finished items and quits when all are done
+func (m BulkEditModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case tea.KeyMsg:
+		if msg.String() != "ctrl+c" {
+			return m, nil
+		}
+		// The first ctrl+c waits for the requests in flight so that the
+		// results are known; a second one quits at once
+		if m.run.stopped {
+			return m, tea.Quit
+		}
+		m.run.Stop()
+		if m.run.Done() {
+			return m, tea.Quit
+		}
+		return m, nil
+	case bulkItemDoneMsg:
+		cmd := m.run.Handle(msg)
+		if m.run.Done() {
+			return m, tea.Quit
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View renders the progress bar
+func (m BulkEditModel) View() string {
+	if m.run.stopped {
+		return fmt.Sprintf("Cancelling, waiting for %d requests in flight (ctrl+c again to quit now)\n%s\n",
+			m.run.next-m.run.finished, m.run.View(m.width))
+	}
+	return fmt.Sprintf("Applying %s\n%s\n", m.run.change, m.run.View(m.width))
+}
+
+// Results returns the outcome for every item; items not reached are marked
+// skipped and those whose request was still pending are marked in flight
+func (m BulkEditModel) Results() []BulkResult {
+	return m.run.results
+}
+
+// PrintBulkPreview lists the items a change will affect with their current values
+func PrintBulkPreview(w io.Writer, items []models.Item, change Change) error {
+	headers := []string{"ITEM", "TITLE"}
+	for _, f := range change.Fields {
+		headers = append(headers, strings.ToUpper(f.Field.Name))
+	}
+	if len(change.AddLabels) > 0 || len(change.RemoveLabels) > 0 {
+		headers = append(headers, "LABELS")
+	}
+	if change.Archive != nil {
+		headers = append(headers, "STATE")
+	}
+
+	t := tui.NewTablePrinter(w, headers...)
+	for _, item := range items {
+		t.AddField(item.Ref())
+		t.AddField(item.Title)
+		after := change.ApplyTo(item)
+		for _, f := range change.Fields {
+			t.AddField(describeChange(item.Value(f.Field.Name), after.Value(f.Field.Name)))
+		}
+		if len(change.AddLabels) > 0 || len(change.RemoveLabels) > 0 {
+			t.AddField(describeChange(strings.Join(item.Labels, ", "), strings.Join(after.Labels, ", ")))
+		}
+		if change.Archive != nil {
+			t.AddField(describeChange(archivedState(item.Archived), archivedState(after.Archived)))
+		}
+		t.EndRow()
+	}
+	return t.Render()
+}
+
+func describeChange(before, after string) string {
+	if before == after {
+		return after + " (unchanged)"
+	}
+	if before == "" {
+		before = "—"
+	}
+	if after == "" {
+		after = "—"
+	}
+	return before + " → " + after
+}
+
+// PrintBulkResults writes the outcome for every item followed by totals
+func PrintBulkResults(w io.Writer, results []BulkResult) error {
+	t := tui.NewTablePrinter(w, "ITEM", "TITLE", "RESULT")
+	var failed, skipped, unknown int
+	for _, r := range results {
+		result := "updated"
+		switch {
+		case r.Skipped:
+			result = "skipped"
+			skipped++
+		case r.InFlight:
+			result = "unknown, cancelled while in flight"
+			unknown++
+		case r.Err != nil:
+			result = "failed: " + r.Err.Error()
+			failed++
+		}
+		t.AddField(r.Item.Ref())
+		t.AddField(r.Item.Title)
+		t.AddField(result)
+		t.EndRow()
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	totals := fmt.Sprintf("\n%d updated, %d failed, %d skipped", len(results)-failed-skipped-unknown, failed, skipped)
+	if unknown > 0 {
+		totals += fmt.Sprintf(", %d unknown; check them with gh pm project view", unknown)
+	}
+	_, err := fmt.Fprintln(w, totals)
+	return err
+}
@@ -0,0 +1,110 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// Pseudo fields offered by the bulk picker next to the project fields
+const (
+	bulkAddLabel    = "+label"
+	bulkRemoveLabel = "-label"
+)
+
+// bulkPicker asks for the field and value to set on the marked items: first
+// a select of the fields, then the editor matching the chosen field
+type bulkPicker struct {
+	project *models.Project
+	count   int
+	width   int
+	form    *huh.Form
+	choice  *string
+	labels  *string
+	editor  *fieldEditor
+}
+
+func newBulkPicker(project *models.Project, count, width int) (*bulkPicker, tea.Cmd) {
+	choice := ""
+	opts := []huh.Option[string]{}
+	for _, f := range project.EditableFields() {
+		opts = append(opts, huh.NewOption(f.Name, f.Name))
+	}
+	opts = append(opts,
+		huh.NewOption("Add labels", bulkAddLabel),
+		huh.NewOption("Remove labels", bulkRemoveLabel),
+	)
+	p := &bulkPicker{project: project, count: count, width: width, choice: &choice}
+	p.form = newEmbeddedForm(width, huh.NewSelect[string]().
+		Title(fmt.Sprintf("Edit %d items", count)).
+		Options(opts...).
+		Value(p.choice))
+	return p, p.form.Init()
+}
+
+// Update passes msg to the current step and reports whether picking finished
+func (p *bulkPicker) Update(msg tea.Msg) (editorState, tea.Cmd) {
+	if p.editor != nil {
+		return p.editor.Update(msg)
+	}
+
+	model, cmd := p.form.Update(msg)
+	if f, ok := model.(*huh.Form); ok {
+		p.form = f
+	}
+	switch p.form.State {
+	case huh.StateAborted:
+		return editorCancelled, nil
+	case huh.StateCompleted:
+		if p.labels != nil {
+			return editorDone, nil
+		}
+		return editorOpen, p.next()
+	}
+	return editorOpen, cmd
+}
+
+// next opens the value step for the chosen field
+func (p *bulkPicker) next() tea.Cmd {
+	choice := *p.choice
+	if choice == bulkAddLabel || choice == bulkRemoveLabel {
+		labels := ""
+		p.labels = &labels
+		p.form = newEmbeddedForm(p.width, huh.NewInput().
+			Title("Labels, separated by commas").
+			Value(p.labels))
+		return p.form.Init()
+	}
+	field := p.project.Field(choice)
+	editor, cmd := newFieldEditor(*field, models.FieldValue{}, p.width)
+	p.editor = editor
+	return cmd
+}
+
+// Change returns the change picked
+func (p *bulkPicker) Change() Change {
+	if p.editor != nil {
+		return Change{Fields: []FieldChange{{Field: p.editor.field, Value: p.editor.Value()}}}
+	}
+	var labels []string
+	for _, l := range strings.Split(*p.labels, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	if *p.choice == bulkAddLabel {
+		return Change{AddLabels: labels}
+	}
+	return Change{RemoveLabels: labels}
+}
+
+// View renders the current step
+func (p *bulkPicker) View() string {
+	if p.editor != nil {
+		return p.editor.View()
+	}
+	return p.form.View()
+}
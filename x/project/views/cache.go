@@ -0,0 +1,38 @@
+package views
+
+import (
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/store"
+)
+
+// LoadCached reads a project from the local cache together with the items
+// matching query, which is evaluated by the database, and the viewer login
+// recorded by the last sync. An empty owner or "@me" refers to that viewer.
+func LoadCached(owner string, number int, query filter.Query) (*models.Project, []models.Item, string, error) {
+	s, err := store.Open()
+	if err != nil {
+		return nil, nil, "", err
+	}
+	defer s.Close()
+
+	viewer, err := s.Meta(store.MetaViewer)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if owner == "" || owner == "@me" {
+		owner = viewer
+	}
+	project, err := s.Project(owner, number)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	where, args, err := query.SQL(filter.Env{Viewer: viewer, Project: project, Now: time.Now()})
+	if err != nil {
+		return nil, nil, "", err
+	}
+	items, err := s.Items(project.ID, where, args...)
+	return project, items, viewer, err
+}
@@ -0,0 +1,243 @@
+package views
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// Built-in columns available in addition to the project's custom fields
+const (
+	ColumnRef        = "Ref"
+	ColumnTitle      = "Title"
+	ColumnAssignees  = "Assignees"
+	ColumnLabels     = "Labels"
+	ColumnMilestone  = "Milestone"
+	ColumnRepository = "Repository"
+	ColumnState      = "State"
+	ColumnType       = "Type"
+	ColumnURL        = "URL"
+	ColumnUpdated    = "Updated"
+)
+
+var builtinColumns = []string{
+	ColumnRef, ColumnTitle, ColumnAssignees, ColumnLabels, ColumnMilestone,
+	ColumnRepository, ColumnState, ColumnType, ColumnURL, ColumnUpdated,
+}
+
+// defaultColumns are shown when no columns are configured
+var defaultColumns = []string{ColumnRef, ColumnTitle, models.StatusField, ColumnAssignees}
+
+// ViewOptions configures how the items of a project are laid out
+type ViewOptions struct {
+	// Layout is "board" or "table"
+	Layout string
+	// Columns lists the visible table columns in order
+	Columns []string
+	// Sort names the column to sort by, suffixed with ":desc" for descending order
+	Sort string
+	// Group names the column whose values split the table into groups
+	Group string
+	// Filter is an item query in the syntax of the filter package, such as
+	// `status:"In Progress" assignee:@me`
+	Filter string
+	// Cached reads the project from the local cache instead of the API
+	Cached bool
+	// Archived shows the archived items instead of the others
+	Archived bool
+}
+
+// Layouts supported by the project view
+const (
+	LayoutBoard = "board"
+	LayoutTable = "table"
+)
+
+// sortSpec splits Sort into the column name and direction
+func (o ViewOptions) sortSpec() (column string, desc bool) {
+	column, dir, _ := strings.Cut(o.Sort, ":")
+	return column, strings.EqualFold(dir, "desc")
+}
+
+// AvailableColumns returns the built-in columns followed by the project's custom fields
+func AvailableColumns(project *models.Project) []string {
+	columns := append([]string{}, builtinColumns...)
+	for _, f := range project.Fields {
+		if f.Editable() {
+			columns = append(columns, f.Name)
+		}
+	}
+	return columns
+}
+
+// CheckColumns returns the columns spelled as the project names them, or an
+// error naming the first one it does not have
+func CheckColumns(project *models.Project, columns []string) ([]string, error) {
+	available := AvailableColumns(project)
+	checked := make([]string, 0, len(columns))
+	for _, c := range columns {
+		i := slices.IndexFunc(available, func(a string) bool { return strings.EqualFold(a, c) })
+		if i < 0 {
+			return nil, clierr.Invalidf("unknown column %q, expected one of %s", c, strings.Join(available, ", "))
+		}
+		checked = append(checked, available[i])
+	}
+	return checked, nil
+}
+
+// Check returns the options with the columns, sort and group spelled as the
+// project names them, as values are looked up by their exact name
+func (o ViewOptions) Check(project *models.Project) (ViewOptions, error) {
+	columns, err := CheckColumns(project, o.Columns)
+	if err != nil {
+		return o, err
+	}
+	if len(columns) > 0 {
+		o.Columns = columns
+	}
+	if column, desc := o.sortSpec(); column != "" {
+		checked, err := CheckColumns(project, []string{column})
+		if err != nil {
+			return o, err
+		}
+		o.Sort = checked[0]
+		if desc {
+			o.Sort += ":" + sortDescending
+		}
+	}
+	if o.Group != "" {
+		checked, err := CheckColumns(project, []string{o.Group})
+		if err != nil {
+			return o, err
+		}
+		o.Group = checked[0]
+	}
+	return o, nil
+}
+
+// columnValue returns the display value of a column for an item
+func columnValue(item models.Item, column string) string {
+	switch column {
+	case ColumnRef:
+		return item.Ref()
+	case ColumnTitle:
+		return item.Title
+	case ColumnAssignees:
+		return strings.Join(item.Assignees, ", ")
+	case ColumnLabels:
+		return strings.Join(item.Labels, ", ")
+	case ColumnMilestone:
+		return item.Milestone
+	case ColumnRepository:
+		return item.Repository
+	case ColumnState:
+		return item.State
+	case ColumnType:
+		return item.Type
+	case ColumnURL:
+		return item.URL
+	case ColumnUpdated:
+		if item.UpdatedAt.IsZero() {
+			return ""
+		}
+		return item.UpdatedAt.Format("2006-01-02")
+	}
+	return item.Value(column)
+}
+
+// columnRank returns the position of value in the column's natural order: option
+// order for single select fields and iteration order for iteration fields.
+// It returns -1 for values without a natural order.
+func columnRank(project *models.Project, column, value string) int {
+	field := project.Field(column)
+	if field == nil {
+		return -1
+	}
+	for i, o := range field.Options {
+		if o.Name == value {
+			return i
+		}
+	}
+	for i, it := range field.Iterations {
+		if it.Title == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// compareColumn orders two values of a column, always placing empty values last
+func compareColumn(project *models.Project, column, a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	if ra, rb := columnRank(project, column, a), columnRank(project, column, b); ra >= 0 && rb >= 0 {
+		return ra - rb
+	}
+	if na, err := strconv.ParseFloat(a, 64); err == nil {
+		if nb, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case na < nb:
+				return -1
+			case na > nb:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// sortItems sorts items in place by column, keeping the original order for ties
+func sortItems(project *models.Project, items []models.Item, column string, desc bool) {
+	if column == "" {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := columnValue(items[i], column), columnValue(items[j], column)
+		// Empty values stay last in both directions
+		if desc && a != "" && b != "" {
+			a, b = b, a
+		}
+		return compareColumn(project, column, a, b) < 0
+	})
+}
+
+type itemGroup struct {
+	name  string
+	items []models.Item
+}
+
+// groupItems splits items by the value of column, ordering groups like sortItems
+func groupItems(project *models.Project, items []models.Item, column string) []itemGroup {
+	var groups []itemGroup
+	index := map[string]int{}
+	for _, item := range items {
+		value := columnValue(item, column)
+		i, ok := index[value]
+		if !ok {
+			i = len(groups)
+			index[value] = i
+			groups = append(groups, itemGroup{name: value})
+		}
+		groups[i].items = append(groups[i].items, item)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return compareColumn(project, column, groups[i].name, groups[j].name) < 0
+	})
+	for i := range groups {
+		if groups[i].name == "" {
+			groups[i].name = "No " + column
+		}
+	}
+	return groups
+}
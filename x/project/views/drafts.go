@@ -0,0 +1,214 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// Settings of a draft issue recorded in the journal
+const (
+	draftTitle = "title"
+	draftBody  = "body"
+)
+
+// AddDraft adds a draft issue to project, sets the given fields on it and
+// records both in rec. When setting a field fails the draft is still returned
+// along with the error.
+func AddDraft(rec *journal.Recorder, project *models.Project, title, body string, fields []FieldChange) (models.Item, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return models.Item{}, fmt.Errorf("the draft needs a title")
+	}
+	item, err := ghc.AddDraft(project.ID, title, body)
+	if err != nil {
+		return models.Item{}, err
+	}
+	rec.ItemAdded(project.ID, item)
+	change := Change{Fields: fields}
+	if err := change.Apply(rec, project.ID, item); err != nil {
+		return item, fmt.Errorf("added the draft, but %w", err)
+	}
+	return change.ApplyTo(item), nil
+}
+
+// EditDraft changes the title and body of a draft item, recording each
+// change in rec; nil values are left as they are
+func EditDraft(rec *journal.Recorder, projectID string, item models.Item, title, body *string) (models.Item, error) {
+	if item.Type != models.ItemTypeDraftIssue {
+		return item, fmt.Errorf("%s is not a draft issue", item.Ref())
+	}
+	if title != nil && strings.TrimSpace(*title) == "" {
+		return item, fmt.Errorf("the draft needs a title")
+	}
+	if err := ghc.UpdateDraft(item.ContentID, title, body); err != nil {
+		return item, err
+	}
+	if title != nil && *title != item.Title {
+		rec.DraftEdited(projectID, item, draftTitle, item.Title, *title)
+		item.Title = *title
+	}
+	if body != nil && *body != item.Body {
+		rec.DraftEdited(projectID, item, draftBody, item.Body, *body)
+		item.Body = *body
+	}
+	return item, nil
+}
+
+// ConvertDraft turns a draft item into an issue of repo, given as owner/name,
+// and sets again any field value the conversion did not keep
+func ConvertDraft(rec *journal.Recorder, project *models.Project, item models.Item, repo string) (models.Item, error) {
+	if item.Type != models.ItemTypeDraftIssue {
+		return item, fmt.Errorf("%s is not a draft issue", item.Ref())
+	}
+	converted, err := ghc.ConvertDraft(item.ID, repo)
+	if err != nil {
+		return item, err
+	}
+	rec.DraftConverted(project.ID, item, repo)
+	for _, field := range project.EditableFields() {
+		want, ok := item.Values[field.Name]
+		if !ok || converted.Values[field.Name].Same(want) {
+			continue
+		}
+		if err := ghc.SetItemField(project.ID, converted.ID, field, want); err != nil {
+			return converted, fmt.Errorf("converted the draft, but could not keep %s: %w", field.Name, err)
+		}
+		converted.SetValue(field.Name, want)
+	}
+	return converted, nil
+}
+
+// Message types for drafts created and converted from the project view
+type (
+	draftAddedMsg struct {
+		item       models.Item
+		err        error
+		journalErr error
+	}
+
+	reposLoadedMsg struct {
+		item  models.Item
+		repos []string
+		err   error
+	}
+
+	draftConvertedMsg struct {
+		draft      models.Item
+		item       models.Item
+		err        error
+		journalErr error
+	}
+)
+
+// Forms shown by the project view
+const (
+	formAddDraft = "add-draft"
+	formConvert  = "convert"
+)
+
+// openDraftForm asks for the title of a draft to add to the selected board
+// column, or without a status in the table
+func (m ProjectViewModel) openDraftForm() (tea.Model, tea.Cmd) {
+	status := ""
+	if m.layout == LayoutBoard {
+		status = m.board.SelectedColumn()
+	}
+	title := "New draft"
+	if status != "" {
+		title += " in " + status
+	}
+	m.formKind = formAddDraft
+	m.formValue = new(string)
+	m.formStatus = status
+	m.form = newEmbeddedForm(m.width, huh.NewInput().Title(title).Placeholder("Title").Value(m.formValue))
+	return m, m.form.Init()
+}
+
+// openConvertForm asks for the repository to convert a draft into
+func (m ProjectViewModel) openConvertForm(msg reposLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = fmt.Sprintf("Could not list repositories: %v", msg.err)
+		return m, nil
+	}
+	if len(msg.repos) == 0 {
+		m.status = fmt.Sprintf("%s has no repositories to convert into, use 'gh pm project item convert --repo'", m.project.Owner)
+		return m, nil
+	}
+	m.formKind = formConvert
+	m.formValue = new(string)
+	m.formItem = msg.item
+	m.form = newEmbeddedForm(m.width, huh.NewSelect[string]().
+		Title(fmt.Sprintf("Convert %q to an issue of", msg.item.Title)).
+		Options(huh.NewOptions(msg.repos...)...).
+		Value(m.formValue))
+	return m, m.form.Init()
+}
+
+// updateForm passes msg to the open form and runs its action once submitted
+func (m ProjectViewModel) updateForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.form.Update(msg)
+	if f, ok := model.(*huh.Form); ok {
+		m.form = f
+	}
+	switch m.form.State {
+	case huh.StateAborted:
+		m.form = nil
+		return m, nil
+	case huh.StateCompleted:
+		m.form = nil
+		switch m.formKind {
+		case formAddDraft:
+			if strings.TrimSpace(*m.formValue) == "" {
+				return m, nil
+			}
+			m.status = "Adding draft..."
+			return m, m.addDraft(*m.formValue, m.formStatus)
+		case formConvert:
+			m.status = fmt.Sprintf("Converting %q...", m.formItem.Title)
+			return m, m.convertDraft(m.formItem, *m.formValue)
+		}
+	}
+	return m, cmd
+}
+
+// loadRepos lists the repositories a draft can be converted into
+func (m ProjectViewModel) loadRepos(item models.Item) tea.Cmd {
+	owner := m.project.Owner
+	return func() tea.Msg {
+		repos, err := ghc.GetOwnerRepos(owner)
+		return reposLoadedMsg{item: item, repos: repos, err: err}
+	}
+}
+
+// addDraft adds a draft with the given status, which may be empty
+func (m ProjectViewModel) addDraft(title, status string) tea.Cmd {
+	project := m.project
+	return func() tea.Msg {
+		var fields []FieldChange
+		if field := project.Field(models.StatusField); field != nil && status != "" {
+			if v, err := field.ParseValue(status, time.Now()); err == nil {
+				fields = append(fields, FieldChange{Field: *field, Value: v})
+			}
+		}
+		rec := journal.Begin("project view")
+		item, err := AddDraft(rec, project, title, "", fields)
+		return draftAddedMsg{item: item, err: err, journalErr: rec.Err()}
+	}
+}
+
+// convertDraft converts a draft into an issue of repo
+func (m ProjectViewModel) convertDraft(draft models.Item, repo string) tea.Cmd {
+	project := m.project
+	return func() tea.Msg {
+		rec := journal.Begin("project view")
+		item, err := ConvertDraft(rec, project, draft, repo)
+		return draftConvertedMsg{draft: draft, item: item, err: err, journalErr: rec.Err()}
+	}
+}
@@ -0,0 +1,118 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+// editorState tells the owner of a fieldEditor whether editing has finished
+type editorState int
+
+const (
+	editorOpen editorState = iota
+	editorCancelled
+	editorDone
+)
+
+// fieldEditor edits the value of a single field with the input matching its
+// type: a select for options and iterations, a date picker for dates and a
+// text input otherwise
+type fieldEditor struct {
+	field      models.Field
+	form       *huh.Form
+	value      *string
+	datePicker *tui.DatePicker
+}
+
+// newFieldEditor opens an editor for field starting from current
+func newFieldEditor(field models.Field, current models.FieldValue, width int) (*fieldEditor, tea.Cmd) {
+	e := &fieldEditor{field: field}
+
+	if field.Type == models.FieldTypeDate {
+		t, _ := time.Parse(time.DateOnly, current.Text)
+		dp := tui.NewDatePicker(field.Name, t)
+		e.datePicker = &dp
+		return e, nil
+	}
+
+	value := current.Text
+	var input huh.Field
+	switch field.Type {
+	case models.FieldTypeSingleSelect:
+		value = current.OptionID
+		opts := []huh.Option[string]{huh.NewOption("(none)", "")}
+		for _, o := range field.Options {
+			opts = append(opts, huh.NewOption(o.Name, o.ID))
+		}
+		input = huh.NewSelect[string]().Title(field.Name).Options(opts...).Value(&value)
+	case models.FieldTypeIteration:
+		value = current.IterationID
+		opts := []huh.Option[string]{huh.NewOption("(none)", "")}
+		for _, it := range field.Iterations {
+			opts = append(opts, huh.NewOption(fmt.Sprintf("%s (%s)", it.Title, it.StartDate), it.ID))
+		}
+		input = huh.NewSelect[string]().Title(field.Name).Options(opts...).Value(&value)
+	case models.FieldTypeNumber:
+		input = huh.NewInput().Title(field.Name).Validate(validateNumber).Value(&value)
+	default:
+		input = huh.NewInput().Title(field.Name).Value(&value)
+	}
+	e.value = &value
+	e.form = newEmbeddedForm(width, input)
+	return e, e.form.Init()
+}
+
+// Update passes msg to the open input and reports whether editing finished
+func (e *fieldEditor) Update(msg tea.Msg) (editorState, tea.Cmd) {
+	if e.datePicker != nil {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			switch key.String() {
+			case "esc":
+				return editorCancelled, nil
+			case "enter":
+				return editorDone, nil
+			case "backspace", "delete", "x":
+				e.datePicker = nil
+				e.value = new(string)
+				return editorDone, nil
+			}
+		}
+		dp, cmd := e.datePicker.Update(msg)
+		e.datePicker = &dp
+		return editorOpen, cmd
+	}
+
+	model, cmd := e.form.Update(msg)
+	if f, ok := model.(*huh.Form); ok {
+		e.form = f
+	}
+	switch e.form.State {
+	case huh.StateAborted:
+		return editorCancelled, nil
+	case huh.StateCompleted:
+		return editorDone, nil
+	}
+	return editorOpen, cmd
+}
+
+// Value returns the edited value once the editor is done
+func (e *fieldEditor) Value() models.FieldValue {
+	if e.datePicker != nil {
+		return models.FieldValue{Text: e.datePicker.Value().Format(time.DateOnly)}
+	}
+	return fieldValue(e.field, *e.value)
+}
+
+// View renders the input followed by its key help
+func (e *fieldEditor) View() string {
+	if e.datePicker != nil {
+		return e.datePicker.View() + "\n\n" +
+			tui.Footer("←/→/↑/↓: Move • [/]: Month • t: Today • x: Clear • Enter: Save • Esc: Cancel")
+	}
+	return e.form.View() + "\n\n" + tui.Footer("Enter: Save • Esc: Cancel")
+}
@@ -0,0 +1,55 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+// PrintFields writes the fields of a project with their type and configuration
+func PrintFields(w io.Writer, project *models.Project) error {
+	t := tui.NewTablePrinter(w, "NAME", "TYPE", "CONFIGURATION")
+	for _, f := range project.Fields {
+		t.AddField(f.Name)
+		t.AddField(FieldTypeName(f.Type))
+		t.AddField(DescribeFieldConfig(f))
+		t.EndRow()
+	}
+	return t.Render()
+}
+
+// FieldTypeName returns the lowercase name of a field type, e.g. "single select"
+func FieldTypeName(t models.FieldType) string {
+	return strings.ReplaceAll(strings.ToLower(string(t)), "_", " ")
+}
+
+// DescribeFieldConfig summarizes the options of a single select field or the
+// iterations of an iteration field, and returns "" for other fields
+func DescribeFieldConfig(f models.Field) string {
+	switch f.Type {
+	case models.FieldTypeSingleSelect:
+		options := make([]string, 0, len(f.Options))
+		for _, o := range f.Options {
+			options = append(options, DescribeOption(o))
+		}
+		return strings.Join(options, ", ")
+	case models.FieldTypeIteration:
+		return fmt.Sprintf("%d days from %s, %d iterations", f.IterationDuration, f.IterationStartDay, len(f.Iterations))
+	}
+	return ""
+}
+
+// DescribeOption returns an option as "Name (color): description"
+func DescribeOption(o models.FieldOption) string {
+	s := o.Name
+	if o.Color != "" {
+		s += " (" + strings.ToLower(o.Color) + ")"
+	}
+	if o.Description != "" {
+		s += ": " + o.Description
+	}
+	return s
+}
@@ -0,0 +1,269 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/pkg/browser"
+	"github.com/cli/go-gh/pkg/markdown"
+	"github.com/cli/go-gh/pkg/term"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+var (
+	detailLabelStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	detailMutedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	detailSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#2D3142"))
+)
+
+// Message types for the item detail view
+type (
+	closeDetailMsg struct{}
+
+	// browsedMsg reports the outcome of opening an item in the browser
+	browsedMsg struct{ err error }
+
+	// fieldEditMsg asks the project view to set a field value on an item
+	fieldEditMsg struct {
+		itemID string
+		field  models.Field
+		value  models.FieldValue
+	}
+)
+
+// ItemDetailModel shows a single project item with editors for its custom fields
+type ItemDetailModel struct {
+	project  *models.Project
+	item     models.Item
+	fields   []models.Field
+	cursor   int
+	viewport viewport.Model
+	body     string
+	width    int
+	height   int
+
+	// editor is set while a field value is being edited
+	editor *fieldEditor
+}
+
+// NewItemDetailModel creates a detail view for item
+func NewItemDetailModel(project *models.Project, item models.Item, width, height int) ItemDetailModel {
+	m := ItemDetailModel{
+		project:  project,
+		fields:   project.EditableFields(),
+		viewport: viewport.New(width, max(height-2, 1)),
+		width:    width,
+		height:   height,
+	}
+	m.SetItem(item)
+	return m
+}
+
+// SetItem replaces the displayed item, used after edits are applied or reverted
+func (m *ItemDetailModel) SetItem(item models.Item) {
+	m.item = item
+	m.body = renderBody(item.Body, m.width)
+	m.viewport.SetContent(m.content())
+}
+
+// SetSize resizes the view and re-renders the body to the new width
+func (m *ItemDetailModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.viewport.Width = width
+	m.viewport.Height = max(height-2, 1)
+	m.SetItem(m.item)
+}
+
+// Update handles navigation, editor input and scrolling
+func (m ItemDetailModel) Update(msg tea.Msg) (ItemDetailModel, tea.Cmd) {
+	if m.editor != nil {
+		return m.updateEditor(msg)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q":
+			return m, func() tea.Msg { return closeDetailMsg{} }
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			m.viewport.SetContent(m.content())
+			return m, nil
+		case "down", "j":
+			if m.cursor < len(m.fields)-1 {
+				m.cursor++
+			}
+			m.viewport.SetContent(m.content())
+			return m, nil
+		case "enter", "e":
+			if len(m.fields) == 0 {
+				return m, nil
+			}
+			cmd := m.startEdit()
+			return m, cmd
+		case "o":
+			if m.item.URL == "" {
+				return m, nil
+			}
+			return m, browse(m.item.URL)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// browse opens url in the browser without writing over the terminal view
+func browse(url string) tea.Cmd {
+	return func() tea.Msg {
+		b := browser.New("", io.Discard, io.Discard)
+		return browsedMsg{err: b.Browse(url)}
+	}
+}
+
+// startEdit opens the editor matching the type of the field under the cursor
+func (m *ItemDetailModel) startEdit() tea.Cmd {
+	field := m.fields[m.cursor]
+	editor, cmd := newFieldEditor(field, m.item.Values[field.Name], m.width)
+	m.editor = editor
+	return cmd
+}
+
+func (m ItemDetailModel) updateEditor(msg tea.Msg) (ItemDetailModel, tea.Cmd) {
+	state, cmd := m.editor.Update(msg)
+	switch state {
+	case editorCancelled:
+		m.editor = nil
+		return m, nil
+	case editorDone:
+		field, value := m.editor.field, m.editor.Value()
+		m.editor = nil
+		return m, m.emitEdit(field, value)
+	}
+	return m, cmd
+}
+
+func (m ItemDetailModel) emitEdit(field models.Field, value models.FieldValue) tea.Cmd {
+	itemID := m.item.ID
+	return func() tea.Msg {
+		return fieldEditMsg{itemID: itemID, field: field, value: value}
+	}
+}
+
+// View renders the item, or the open editor beneath the item header
+func (m ItemDetailModel) View() string {
+	header := tui.Header(fmt.Sprintf("%s %s", m.item.Ref(), m.item.Title))
+	if m.editor != nil {
+		return header + "\n\n" + m.editor.View()
+	}
+	return header + "\n" + m.viewport.View() + "\n" +
+		tui.Footer("↑/↓: Field • Enter: Edit • o: Open in browser • PgUp/PgDn: Scroll • Esc: Back")
+}
+
+// content renders the metadata, field values and body shown in the viewport
+func (m ItemDetailModel) content() string {
+	var sb strings.Builder
+	row := func(label, value string) {
+		if value == "" {
+			value = detailMutedStyle.Render("—")
+		}
+		sb.WriteString(detailLabelStyle.Render(fmt.Sprintf("%-14s", label)))
+		sb.WriteString(value)
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	if m.item.Type != models.ItemTypeDraftIssue {
+		row("State", m.item.State)
+		row("URL", m.item.URL)
+	}
+	row("Assignees", strings.Join(m.item.Assignees, ", "))
+	row("Labels", strings.Join(m.item.Labels, ", "))
+	row("Milestone", m.item.Milestone)
+	if len(m.item.LinkedPRs) > 0 {
+		row("Linked PRs", strings.Join(m.item.LinkedPRs, "\n"+strings.Repeat(" ", 14)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(tui.Header("Fields"))
+	sb.WriteString("\n")
+	for i, f := range m.fields {
+		value := m.item.Value(f.Name)
+		if value == "" {
+			value = "—"
+		}
+		line := fmt.Sprintf("%-20s %s", f.Name, value)
+		if i == m.cursor {
+			line = detailSelectedStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(m.body)
+	return sb.String()
+}
+
+// renderBody renders markdown for the terminal, falling back to the raw text
+func renderBody(body string, width int) string {
+	if strings.TrimSpace(body) == "" {
+		return detailMutedStyle.Render("No description provided.")
+	}
+	out, err := markdown.Render(body,
+		markdown.WithTheme(term.FromEnv().Theme()),
+		markdown.WithWrap(max(width-4, 20)),
+	)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func validateNumber(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+		return fmt.Errorf("%q is not a number", s)
+	}
+	return nil
+}
+
+// fieldValue converts editor input into a field value; raw holds the option
+// or iteration ID for select fields and the typed text otherwise
+func fieldValue(field models.Field, raw string) models.FieldValue {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return models.FieldValue{}
+	}
+	switch field.Type {
+	case models.FieldTypeSingleSelect:
+		for _, o := range field.Options {
+			if o.ID == raw {
+				return models.FieldValue{Text: o.Name, OptionID: o.ID}
+			}
+		}
+	case models.FieldTypeIteration:
+		for _, it := range field.Iterations {
+			if it.ID == raw {
+				return models.FieldValue{Text: it.Title, IterationID: it.ID}
+			}
+		}
+	case models.FieldTypeNumber:
+		n, _ := strconv.ParseFloat(raw, 64)
+		return models.FieldValue{Text: strconv.FormatFloat(n, 'f', -1, 64), Number: n}
+	}
+	return models.FieldValue{Text: raw}
+}
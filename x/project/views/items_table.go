@@ -0,0 +1,54 @@
+package views
+
+import (
+	"io"
+	"slices"
+
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+// PrintItems writes the project items matching opts as a plain table, the
+// archived ones when opts.Archived is set
+// for non-interactive output. viewer resolves @me in the filter.
+func PrintItems(w io.Writer, project *models.Project, items []models.Item, opts ViewOptions, viewer string) error {
+	query, err := filter.Parse(opts.Filter)
+	if err != nil {
+		return err
+	}
+	if opts, err = opts.Check(project); err != nil {
+		return err
+	}
+	env := filter.Env{Viewer: viewer, Project: project}
+
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = append(slices.Clone(defaultColumns), ColumnURL)
+	}
+
+	matched := make([]models.Item, 0, len(items))
+	for _, item := range items {
+		if item.Archived == opts.Archived && query.Match(item, env) {
+			matched = append(matched, item)
+		}
+	}
+	sortBy, desc := opts.sortSpec()
+	sortItems(project, matched, sortBy, desc)
+	// Grouping sorts by the group column last and prints it first
+	if opts.Group != "" {
+		sortItems(project, matched, opts.Group, false)
+		columns = append([]string{opts.Group}, slices.DeleteFunc(slices.Clone(columns), func(c string) bool {
+			return c == opts.Group
+		})...)
+	}
+
+	t := tui.NewTablePrinter(w, columns...)
+	for _, item := range matched {
+		for _, c := range columns {
+			t.AddField(columnValue(item, c))
+		}
+		t.EndRow()
+	}
+	return t.Render()
+}
@@ -4,18 +4,38 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/spec"
 	"github.com/prnk28/gh-pm/internal/tui"
 )
 
+// Templates a new project can start from
+const (
+	TemplateNone    = ""
+	TemplateProject = "project"
+	TemplateYAML    = "yaml"
+)
+
 // ProjectForm represents the data collected from the project creation form
 type ProjectForm struct {
 	Title        string
 	Organization string
 	Description  string
 	Submitted    bool
+
+	// Template is what the project starts from: nothing, a copy of another project or a YAML file
+	Template string
+	// TemplateOwner and TemplateNumber locate the project copied with TemplateProject
+	TemplateOwner  string
+	TemplateNumber int
+	// CopyDrafts copies the draft issues of the template project as well
+	CopyDrafts bool
+	// TemplatePath is the file written by 'gh pm project export' used with TemplateYAML
+	TemplatePath string
 }
 
 // NewProjectForm creates a new project form using Huh
@@ -69,6 +89,57 @@ func NewProjectForm(ctx *ctx.Context) (*ProjectForm, error) {
 				Lines(5).
 				Value(&form.Description),
 
+			huh.NewSelect[string]().
+				Title("Start From").
+				Description("Copy the fields and views of an existing project or a YAML template").
+				Options(
+					huh.NewOption("Blank project", TemplateNone),
+					huh.NewOption("Existing project", TemplateProject),
+					huh.NewOption("YAML template", TemplateYAML),
+				).
+				Value(&form.Template),
+		),
+
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Template Owner").
+				Description("Select the owner of the project to copy").
+				Options(orgOptions...).
+				Value(&form.TemplateOwner),
+
+			huh.NewSelect[int]().
+				Title("Template Project").
+				Description("Its fields, options, views and workflows are copied").
+				OptionsFunc(func() []huh.Option[int] {
+					return templateOptions(form.TemplateOwner)
+				}, &form.TemplateOwner).
+				Validate(func(n int) error {
+					if n <= 0 {
+						return fmt.Errorf("select a project to copy")
+					}
+					return nil
+				}).
+				Value(&form.TemplateNumber),
+
+			huh.NewConfirm().
+				Title("Copy Draft Issues?").
+				Description("Draft issues of the template are copied as well").
+				Value(&form.CopyDrafts),
+		).WithHideFunc(func() bool { return form.Template != TemplateProject }),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Template File").
+				Description("A file written by 'gh pm project export'").
+				Placeholder("project.yaml").
+				Validate(func(path string) error {
+					_, err := spec.Load(path)
+					return err
+				}).
+				Value(&form.TemplatePath),
+		).WithHideFunc(func() bool { return form.Template != TemplateYAML }),
+
+		huh.NewGroup(
 			huh.NewConfirm().
 				Title("Create Project?").
 				Description("Are you ready to create this project?").
@@ -86,6 +157,22 @@ func NewProjectForm(ctx *ctx.Context) (*ProjectForm, error) {
 	return form, nil
 }
 
+// templateOptions lists the projects of owner that can be copied, or of the viewer when owner is empty
+func templateOptions(owner string) []huh.Option[int] {
+	projects, err := ghc.GetOwnerProjects(owner)
+	if err != nil {
+		return []huh.Option[int]{huh.NewOption(fmt.Sprintf("Could not list projects: %v", err), 0)}
+	}
+	if len(projects) == 0 {
+		return []huh.Option[int]{huh.NewOption("No projects", 0)}
+	}
+	opts := make([]huh.Option[int], 0, len(projects))
+	for _, p := range projects {
+		opts = append(opts, huh.NewOption(fmt.Sprintf("#%d %s", int(p.Number), p.Title), int(p.Number)))
+	}
+	return opts
+}
+
 // customTheme returns a custom theme for the form
 func customTheme() *huh.Theme {
 	t := huh.ThemeCharm()
@@ -98,6 +185,36 @@ func customTheme() *huh.Theme {
 	return t
 }
 
+// newEmbeddedForm creates a single group form hosted inside another view, where esc cancels the form
+func newEmbeddedForm(width int, fields ...huh.Field) *huh.Form {
+	keymap := huh.NewDefaultKeyMap()
+	keymap.Quit = key.NewBinding(key.WithKeys("esc", "ctrl+c"))
+	return huh.NewForm(huh.NewGroup(fields...)).
+		WithShowHelp(false).
+		WithKeyMap(keymap).
+		WithWidth(min(width, 60)).
+		WithTheme(customTheme())
+}
+
+// TemplateName describes the template the project starts from, or "" for a blank project
+func (f *ProjectForm) TemplateName() string {
+	switch f.Template {
+	case TemplateProject:
+		owner := f.TemplateOwner
+		if owner == "" {
+			owner = "@me"
+		}
+		name := fmt.Sprintf("project %s/%d", owner, f.TemplateNumber)
+		if f.CopyDrafts {
+			name += " with draft issues"
+		}
+		return name
+	case TemplateYAML:
+		return f.TemplatePath
+	}
+	return ""
+}
+
 // FormatSummary returns a formatted summary of the form data
 func (f *ProjectForm) FormatSummary() string {
 	var sb strings.Builder
@@ -123,6 +240,13 @@ func (f *ProjectForm) FormatSummary() string {
 	sb.WriteString(detailStyle.Render(org))
 	sb.WriteString("\n\n")
 
+	if template := f.TemplateName(); template != "" {
+		sb.WriteString(titleStyle.Render("Template:"))
+		sb.WriteString(" ")
+		sb.WriteString(detailStyle.Render(template))
+		sb.WriteString("\n\n")
+	}
+
 	if f.Description != "" {
 		sb.WriteString(titleStyle.Render("Description:"))
 		sb.WriteString("\n")
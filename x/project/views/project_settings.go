@@ -0,0 +1,128 @@
+package views
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// SaveSettings changes the settings of p to values with a single update and
+// records each change in rec. Settings already holding their value are skipped;
+// the names of the changed settings are returned and p is updated to match.
+func SaveSettings(rec *journal.Recorder, p *models.Project, values map[string]string) ([]string, error) {
+	var edit ghc.ProjectEdit
+	var changed []string
+	for _, name := range models.Settings {
+		value, ok := values[name]
+		if !ok || value == p.Setting(name) {
+			continue
+		}
+		if err := edit.Set(name, value); err != nil {
+			return nil, err
+		}
+		changed = append(changed, name)
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	if err := ghc.UpdateProject(p.ID, edit); err != nil {
+		return nil, err
+	}
+	for _, name := range changed {
+		from := p.Setting(name)
+		p.SetSetting(name, values[name])
+		rec.ProjectEdited(p, name, from, values[name])
+	}
+	return changed, nil
+}
+
+// settingsHeader explains the document written by WriteSettingsFile
+const settingsHeader = `# Edit the title and short description of the project below. The readme
+# follows the --- line and is written in Markdown. Lines starting with # above
+# the --- line are ignored. Save and quit the editor to apply the changes.
+`
+
+// WriteSettingsFile writes the title, description and readme of p to a
+// temporary file to be opened in an editor, and returns its path
+func WriteSettingsFile(p *models.Project) (string, error) {
+	f, err := os.CreateTemp("", "gh-pm-project-*.md")
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprint(f, settingsHeader)
+	fmt.Fprintf(f, "title: %s\n", p.Title)
+	fmt.Fprintf(f, "description: %s\n", p.ShortDescription)
+	fmt.Fprintf(f, "---\n%s", p.Readme)
+	if p.Readme != "" && !strings.HasSuffix(p.Readme, "\n") {
+		fmt.Fprintln(f)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// ReadSettingsFile parses a file written by WriteSettingsFile for p after
+// editing, removes it and returns the title, description and readme keyed by
+// setting
+func ReadSettingsFile(path string, p *models.Project) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	os.Remove(path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseSettings(string(data))
+	if err != nil {
+		return nil, err
+	}
+	// Trailing newlines of the readme do not survive the round trip
+	if values[models.SettingReadme] == strings.TrimRight(p.Readme, "\n") {
+		delete(values, models.SettingReadme)
+	}
+	return values, nil
+}
+
+// parseSettings parses the document written by WriteSettingsFile
+func parseSettings(doc string) (map[string]string, error) {
+	values := map[string]string{}
+	var readme strings.Builder
+	inReadme := false
+	scanner := bufio.NewScanner(strings.NewReader(doc))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if inReadme {
+			readme.WriteString(line + "\n")
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "---":
+			inReadme = true
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		default:
+			key, value, ok := strings.Cut(line, ":")
+			key = strings.ToLower(strings.TrimSpace(key))
+			if !ok || (key != models.SettingTitle && key != models.SettingDescription) {
+				return nil, fmt.Errorf("line %d: expected title: or description:, got %q", n, line)
+			}
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if values[models.SettingTitle] == "" {
+		return nil, fmt.Errorf("the project needs a title")
+	}
+	if inReadme {
+		values[models.SettingReadme] = strings.TrimRight(readme.String(), "\n")
+	}
+	return values, nil
+}
@@ -0,0 +1,701 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+// Message types for the project view
+type (
+	projectLoadedMsg struct {
+		project *models.Project
+		items   []models.Item
+		viewer  string
+		err     error
+	}
+
+	// fieldSavedMsg reports the result of a field edit; previous is restored on error
+	fieldSavedMsg struct {
+		itemID     string
+		field      string
+		previous   models.FieldValue
+		err        error
+		journalErr error
+	}
+
+	// backMsg is sent when an embedded project view is closed
+	backMsg struct{}
+)
+
+// ProjectViewModel shows the items of a single project as a board or a table
+type ProjectViewModel struct {
+	owner    string
+	number   int
+	viewer   string
+	project  *models.Project
+	items    []models.Item
+	layout   string
+	filter   string
+	query    filter.Query
+	cached   bool
+	archived bool
+	prompt   textinput.Model
+	board    BoardModel
+	table    TableModel
+	detail   *ItemDetailModel
+	marked   map[string]bool
+	picker   *bulkPicker
+	bulk     *bulkRun
+	before   map[string]models.Item
+	spinner  tui.Spinner
+	loading  bool
+	embedded bool
+	// opts are the layout options to check once the project is loaded
+	opts   *ViewOptions
+	status string
+	err    error
+	width  int
+	height int
+
+	// form asks for a draft title or a repository, see updateForm
+	form       *huh.Form
+	formKind   string
+	formValue  *string
+	formStatus string
+	formItem   models.Item
+
+	// search picks issues and pull requests to add to the project
+	search *searchPicker
+}
+
+// NewProjectViewModel creates a view for project number owned by owner
+func NewProjectViewModel(owner string, number int, opts ViewOptions) ProjectViewModel {
+	layout := opts.Layout
+	if layout != LayoutTable {
+		layout = LayoutBoard
+	}
+	prompt := textinput.New()
+	prompt.Prompt = "Filter: "
+	prompt.Placeholder = `status:"In Progress" assignee:@me -label:bug updated:>7d`
+
+	m := ProjectViewModel{
+		owner:    owner,
+		number:   number,
+		layout:   layout,
+		cached:   opts.Cached,
+		archived: opts.Archived,
+		prompt:   prompt,
+		marked:   map[string]bool{},
+		board:    NewBoardModel(),
+		table:    NewTableModel(opts),
+		spinner:  tui.NewSpinner("Loading project items..."),
+		loading:  true,
+		opts:     &opts,
+	}
+	if err := m.setFilter(opts.Filter); err != nil {
+		m.status = fmt.Sprintf("Invalid filter: %v", err)
+	}
+	return m
+}
+
+// NewLoadedProjectViewModel creates a view for a project whose items were
+// already fetched; reloading fetches them again
+func NewLoadedProjectViewModel(project *models.Project, items []models.Item, viewer string, opts ViewOptions) ProjectViewModel {
+	m := NewProjectViewModel(project.Owner, project.Number, opts)
+	model, _ := m.Update(projectLoadedMsg{project: project, items: items, viewer: viewer})
+	return model.(ProjectViewModel)
+}
+
+// Init initializes the model
+func (m ProjectViewModel) Init() tea.Cmd {
+	if !m.loading {
+		return nil
+	}
+	return tea.Batch(
+		m.spinner.Init(),
+		m.fetchProject,
+	)
+}
+
+// fetchProject fetches the project fields and items from the GitHub API, or
+// from the local cache when the view was opened with the cached option
+func (m ProjectViewModel) fetchProject() tea.Msg {
+	if m.cached {
+		project, items, viewer, err := LoadCached(m.owner, m.number, filter.Query{})
+		return projectLoadedMsg{project: project, items: items, viewer: viewer, err: err}
+	}
+	project, err := ghc.GetProject(m.owner, m.number)
+	if err != nil {
+		return projectLoadedMsg{err: err}
+	}
+	user, err := ghc.GetWhoami()
+	if err != nil {
+		return projectLoadedMsg{err: err}
+	}
+	items, err := ghc.GetItems(project.ID)
+	return projectLoadedMsg{
+		project: project,
+		items:   items,
+		viewer:  user.Login,
+		err:     err,
+	}
+}
+
+// saveField applies a field edit through the API and records it in the journal
+func (m ProjectViewModel) saveField(item models.Item, field models.Field, value, previous models.FieldValue) tea.Cmd {
+	projectID := m.project.ID
+	return func() tea.Msg {
+		msg := fieldSavedMsg{itemID: item.ID, field: field.Name, previous: previous}
+		if msg.err = ghc.SetItemField(projectID, item.ID, field, value); msg.err == nil {
+			rec := journal.Begin("project view")
+			rec.Field(projectID, item, field, previous, value)
+			msg.journalErr = rec.Err()
+		}
+		return msg
+	}
+}
+
+// Update handles messages for the model
+func (m ProjectViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.board.SetSize(msg.Width, msg.Height-4)
+		m.table.SetSize(msg.Width, msg.Height-4)
+		if m.detail != nil {
+			m.detail.SetSize(msg.Width, msg.Height-2)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		// Leaving must not wait for a slow or hung request
+		if m.loading && (msg.String() == "q" || msg.String() == "esc") {
+			if m.embedded {
+				return m, func() tea.Msg { return backMsg{} }
+			}
+			return m, tea.Quit
+		}
+		if m.prompt.Focused() {
+			return m.updatePrompt(msg)
+		}
+		if m.picker != nil {
+			return m.updatePicker(msg)
+		}
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.search != nil {
+			return m.updateSearch(msg)
+		}
+		if m.detail == nil && !m.loading && !m.table.Picking() {
+			switch msg.String() {
+			case " ":
+				if item, ok := m.selectedItem(); ok {
+					if m.marked[item.ID] {
+						delete(m.marked, item.ID)
+					} else {
+						m.marked[item.ID] = true
+					}
+					m.refresh()
+				}
+				return m, nil
+			case "e":
+				if len(m.marked) == 0 || m.bulk != nil {
+					return m, nil
+				}
+				picker, cmd := newBulkPicker(m.project, len(m.marked), m.width)
+				m.picker = picker
+				return m, cmd
+			case "esc":
+				if len(m.marked) > 0 {
+					m.marked = map[string]bool{}
+					m.refresh()
+					return m, nil
+				}
+				if m.embedded {
+					return m, func() tea.Msg { return backMsg{} }
+				}
+				return m, tea.Quit
+			case "q":
+				if m.embedded {
+					return m, func() tea.Msg { return backMsg{} }
+				}
+				return m, tea.Quit
+			case "r":
+				if m.bulk != nil {
+					return m, nil
+				}
+				m.loading = true
+				return m, tea.Batch(m.spinner.Init(), m.fetchProject)
+			case "n":
+				return m.openDraftForm()
+			case "a":
+				search, cmd := newSearchPicker(m.items, m.height)
+				m.search = search
+				return m, cmd
+			case "C":
+				item, ok := m.selectedItem()
+				if !ok || item.Type != models.ItemTypeDraftIssue {
+					m.status = "Select a draft issue to convert"
+					return m, nil
+				}
+				m.status = "Loading repositories..."
+				return m, m.loadRepos(item)
+			case "A":
+				if m.bulk != nil {
+					return m, nil
+				}
+				m.archived = !m.archived
+				m.marked = map[string]bool{}
+				m.status = ""
+				m.refresh()
+				return m, nil
+			case "x":
+				if m.bulk != nil {
+					return m, nil
+				}
+				if len(m.marked) == 0 {
+					item, ok := m.selectedItem()
+					if !ok {
+						return m, nil
+					}
+					m.marked[item.ID] = true
+				}
+				// Archived items leave the view until it is toggled with A
+				archive := !m.archived
+				return m, m.startBulk(Change{Archive: &archive})
+			case "v":
+				if m.layout == LayoutBoard {
+					m.layout = LayoutTable
+				} else {
+					m.layout = LayoutBoard
+				}
+				return m, nil
+			case "/":
+				m.prompt.SetValue(m.filter)
+				m.prompt.CursorEnd()
+				cmd := m.prompt.Focus()
+				return m, cmd
+			}
+		}
+
+	case projectLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if m.opts != nil {
+			// Column names are only known once the project is
+			opts, err := m.opts.Check(msg.project)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.table = NewTableModel(opts)
+			m.table.SetSize(m.width, m.height-4)
+			m.opts = nil
+		}
+		m.project = msg.project
+		m.items = msg.items
+		m.viewer = msg.viewer
+		m.refresh()
+		return m, nil
+
+	case openItemMsg:
+		if i := m.itemIndex(msg.itemID); i >= 0 {
+			detail := NewItemDetailModel(m.project, m.items[i], m.width, m.height-2)
+			m.detail = &detail
+		}
+		return m, nil
+
+	case closeDetailMsg:
+		m.detail = nil
+		return m, nil
+
+	case browsedMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Could not open the browser: %v", msg.err)
+		}
+		return m, nil
+
+	case fieldEditMsg:
+		// Apply the edit right away and revert it if the API call fails
+		i := m.itemIndex(msg.itemID)
+		if i < 0 {
+			return m, nil
+		}
+		item := m.items[i]
+		previous := item.Values[msg.field.Name]
+		m.items[i].Values = cloneValues(item.Values)
+		m.items[i].SetValue(msg.field.Name, msg.value)
+		m.status = fmt.Sprintf("Saving %s...", msg.field.Name)
+		m.refresh()
+		return m, m.saveField(item, msg.field, msg.value, previous)
+
+	case fieldSavedMsg:
+		if msg.err != nil {
+			if i := m.itemIndex(msg.itemID); i >= 0 {
+				m.items[i].SetValue(msg.field, msg.previous)
+			}
+			m.status = fmt.Sprintf("Could not update %s: %v", msg.field, msg.err)
+		} else if msg.journalErr != nil {
+			m.status = fmt.Sprintf("Updated %s, but it cannot be undone: %v", msg.field, msg.journalErr)
+		} else {
+			m.status = fmt.Sprintf("Updated %s", msg.field)
+		}
+		m.refresh()
+		return m, nil
+
+	case searchResultsMsg:
+		if m.search != nil {
+			return m.updateSearch(msg)
+		}
+		return m, nil
+
+	case itemsAddedMsg:
+		failed := 0
+		var firstErr error
+		for _, r := range msg.results {
+			if r.Err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = r.Err
+				}
+				continue
+			}
+			if m.itemIndex(r.Item.ID) < 0 {
+				m.items = append(m.items, r.Item)
+			}
+		}
+		if failed > 0 {
+			m.status = fmt.Sprintf("Added %d of %d items; %d failed: %v", len(msg.results)-failed, len(msg.results), failed, firstErr)
+		} else {
+			m.status = fmt.Sprintf("Added %d items", len(msg.results))
+		}
+		if msg.journalErr != nil {
+			m.status += fmt.Sprintf(" (cannot be undone: %v)", msg.journalErr)
+		}
+		m.refresh()
+		return m, nil
+
+	case reposLoadedMsg:
+		return m.openConvertForm(msg)
+
+	case draftAddedMsg:
+		if msg.item.ID != "" {
+			m.items = append(m.items, msg.item)
+		}
+		switch {
+		case msg.err != nil && msg.item.ID == "":
+			m.status = fmt.Sprintf("Could not add the draft: %v", msg.err)
+		case msg.err != nil:
+			m.status = fmt.Sprintf("%v", msg.err)
+		case msg.journalErr != nil:
+			m.status = fmt.Sprintf("Added %q, but it cannot be undone: %v", msg.item.Title, msg.journalErr)
+		default:
+			m.status = fmt.Sprintf("Added %q", msg.item.Title)
+		}
+		m.refresh()
+		return m, nil
+
+	case draftConvertedMsg:
+		if msg.item.URL != "" {
+			if i := m.itemIndex(msg.draft.ID); i >= 0 {
+				m.items[i] = msg.item
+			}
+		}
+		switch {
+		case msg.err != nil:
+			m.status = fmt.Sprintf("Could not convert %q: %v", msg.draft.Title, msg.err)
+		default:
+			m.status = fmt.Sprintf("Converted %q to %s", msg.draft.Title, msg.item.Ref())
+		}
+		m.refresh()
+		return m, nil
+
+	case bulkItemDoneMsg:
+		if msg.run != m.bulk {
+			return m, nil
+		}
+		cmd := m.bulk.Handle(msg)
+		if !m.bulk.Done() {
+			return m, cmd
+		}
+		m.finishBulk()
+		return m, nil
+	}
+
+	if m.picker != nil {
+		return m.updatePicker(msg)
+	}
+	if m.form != nil {
+		return m.updateForm(msg)
+	}
+	if m.loading {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	if m.detail != nil {
+		var detail ItemDetailModel
+		detail, cmd = m.detail.Update(msg)
+		m.detail = &detail
+		return m, cmd
+	}
+	if m.layout == LayoutTable {
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	m.board, cmd = m.board.Update(msg)
+	return m, cmd
+}
+
+// updatePrompt edits the filter query; enter applies it and esc discards the change
+func (m ProjectViewModel) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.prompt.Blur()
+		if err := m.setFilter(m.prompt.Value()); err != nil {
+			m.status = fmt.Sprintf("Invalid filter: %v", err)
+			return m, nil
+		}
+		m.status = ""
+		m.refresh()
+		return m, nil
+	case "esc":
+		m.prompt.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+// updateSearch feeds msg to the search picker and adds the chosen items once
+// picking finished
+func (m ProjectViewModel) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	state, cmd := m.search.Update(msg)
+	switch state {
+	case editorCancelled:
+		m.search = nil
+		return m, nil
+	case editorDone:
+		chosen := m.search.Chosen()
+		m.search = nil
+		m.status = fmt.Sprintf("Adding %d items...", len(chosen))
+		projectID := m.project.ID
+		return m, func() tea.Msg {
+			rec := journal.Begin("project view")
+			results := AddItems(rec, projectID, chosen, nil)
+			return itemsAddedMsg{results: results, journalErr: rec.Err()}
+		}
+	}
+	return m, cmd
+}
+
+// updatePicker feeds keys to the bulk edit picker and starts the edit once it is complete
+func (m ProjectViewModel) updatePicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	state, cmd := m.picker.Update(msg)
+	switch state {
+	case editorCancelled:
+		m.picker = nil
+		return m, nil
+	case editorDone:
+		change := m.picker.Change()
+		m.picker = nil
+		cmd := m.startBulk(change)
+		return m, cmd
+	}
+	return m, cmd
+}
+
+// startBulk applies change to the marked items right away and saves them in
+// the background, keeping the previous items to restore those that fail
+func (m *ProjectViewModel) startBulk(change Change) tea.Cmd {
+	if change.Empty() {
+		return nil
+	}
+	var items []models.Item
+	m.before = map[string]models.Item{}
+	for i, item := range m.items {
+		if m.marked[item.ID] {
+			items = append(items, item)
+			m.before[item.ID] = item
+			m.items[i] = change.ApplyTo(item)
+		}
+	}
+	m.bulk = newBulkRun(journal.Begin("project view"), m.project.ID, items, change)
+	m.refresh()
+	return m.bulk.Start()
+}
+
+// finishBulk restores the items whose update failed and reports the outcome
+func (m *ProjectViewModel) finishBulk() {
+	var firstErr error
+	for _, r := range m.bulk.results {
+		if r.Err == nil {
+			delete(m.marked, r.Item.ID)
+			continue
+		}
+		if firstErr == nil {
+			firstErr = r.Err
+		}
+		if i := m.itemIndex(r.Item.ID); i >= 0 {
+			m.items[i] = m.before[r.Item.ID]
+		}
+	}
+	total, failed := len(m.bulk.results), m.bulk.Failed()
+	if failed > 0 {
+		m.status = fmt.Sprintf("Updated %d of %d items; %d failed and stay marked: %v", total-failed, total, failed, firstErr)
+	} else {
+		m.status = fmt.Sprintf("Updated %d items: %s", total, m.bulk.change)
+	}
+	if err := m.bulk.rec.Err(); err != nil {
+		m.status += fmt.Sprintf(" (cannot be undone: %v)", err)
+	}
+	m.bulk = nil
+	m.before = nil
+	m.refresh()
+}
+
+// selectedItem returns the item under the cursor of the active layout
+func (m ProjectViewModel) selectedItem() (models.Item, bool) {
+	if m.layout == LayoutTable {
+		return m.table.Selected()
+	}
+	return m.board.Selected()
+}
+
+// setFilter parses and applies a filter query, keeping the current one when it is invalid
+func (m *ProjectViewModel) setFilter(s string) error {
+	query, err := filter.Parse(s)
+	if err != nil {
+		return err
+	}
+	m.filter = strings.TrimSpace(s)
+	m.query = query
+	return nil
+}
+
+// refresh pushes the current items into the layouts and the open detail view
+func (m *ProjectViewModel) refresh() {
+	if m.project == nil {
+		return
+	}
+	items := m.visibleItems()
+	m.board.SetMarked(m.marked)
+	m.board.SetItems(m.project, items)
+	m.table.SetMarked(m.marked)
+	m.table.SetItems(m.project, items)
+	if m.detail != nil {
+		if i := m.itemIndex(m.detail.item.ID); i >= 0 {
+			m.detail.SetItem(m.items[i])
+		}
+	}
+}
+
+// visibleItems returns the items matching the filter, either the archived
+// ones or the others
+func (m ProjectViewModel) visibleItems() []models.Item {
+	env := filter.Env{Viewer: m.viewer, Project: m.project}
+	items := make([]models.Item, 0, len(m.items))
+	for _, item := range m.items {
+		if item.Archived == m.archived && m.query.Match(item, env) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (m ProjectViewModel) itemIndex(id string) int {
+	for i := range m.items {
+		if m.items[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// View renders the model
+func (m ProjectViewModel) View() string {
+	title := fmt.Sprintf("Project #%d", m.number)
+	if m.project != nil {
+		title = fmt.Sprintf("%s #%d: %s", m.project.Owner, m.project.Number, m.project.Title)
+	}
+	if m.archived {
+		title += " (archived items)"
+	}
+
+	if m.err != nil {
+		return tui.Header("Error") + "\n\n" +
+			"Error opening project: " + clierr.Render(m.err) + "\n\n" +
+			tui.Footer("Press q to quit")
+	}
+
+	if m.loading {
+		return tui.Header(title) + "\n\n" +
+			m.spinner.View() + "\n\n" +
+			tui.Footer("Press q to quit")
+	}
+
+	if m.detail != nil {
+		return m.detail.View() + "\n" + m.status
+	}
+	if m.picker != nil {
+		return tui.Header(title) + "\n\n" + m.picker.View()
+	}
+	if m.search != nil {
+		return tui.Header(title) + "\n\n" + m.search.View()
+	}
+	if m.form != nil {
+		return tui.Header(title) + "\n\n" + m.form.View() + "\n\n" + tui.Footer("Enter: Save • Esc: Cancel")
+	}
+
+	body := m.board.View()
+	archive, toggle := "x: Archive", "A: Archived"
+	if m.archived {
+		archive, toggle = "x: Unarchive", "A: Active"
+	}
+	help := fmt.Sprintf("←/→/↑/↓: Navigate • Enter: Open • Space: Mark • a: Add items • n: New draft • C: Convert • /: Filter • %s • %s • v: Table • r: Reload • q: Quit", archive, toggle)
+	if m.layout == LayoutTable {
+		body = m.table.View()
+		help = fmt.Sprintf("Enter: Open • Space: Mark • a: Add items • n: New draft • C: Convert • /: Filter • c: Columns • s/S: Sort • g: Group • %s • %s • v: Board • q: Quit", archive, toggle)
+	}
+	if len(m.marked) > 0 {
+		help = fmt.Sprintf("%d marked • Space: Mark • e: Edit marked • %s marked • Esc: Clear marks", len(m.marked), archive)
+	}
+
+	status := m.status
+	switch {
+	case m.bulk != nil:
+		status = m.bulk.View(m.width)
+	case m.prompt.Focused():
+		status = m.prompt.View()
+	case m.filter != "":
+		status = strings.TrimSpace(fmt.Sprintf("Filter: %s  %s", m.filter, m.status))
+	}
+
+	return strings.Join([]string{
+		tui.Header(title),
+		body,
+		status,
+		tui.Footer(help),
+	}, "\n")
+}
@@ -1,13 +1,18 @@
 package views
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/prnk28/gh-pm/internal/clierr"
 	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
 	"github.com/prnk28/gh-pm/internal/models"
 	"github.com/prnk28/gh-pm/internal/tui"
 )
@@ -18,6 +23,34 @@ type projectsMsg struct {
 	err      error
 }
 
+// projectSavedMsg reports settings of a project changed from the list
+type projectSavedMsg struct {
+	project    models.ProjectsJson
+	changed    []string
+	err        error
+	journalErr error
+}
+
+// projectDeletedMsg reports a project deleted from the list
+type projectDeletedMsg struct {
+	project    models.ProjectsJson
+	err        error
+	journalErr error
+}
+
+// settingsEditedMsg is sent when the editor opened on a project's settings exits
+type settingsEditedMsg struct {
+	project models.ProjectsJson
+	path    string
+	err     error
+}
+
+// Actions confirmed with a form in the projects list
+const (
+	confirmDelete  = "delete"
+	confirmPublish = "publish"
+)
+
 // ProjectItem represents a project in the list
 type ProjectItem struct {
 	OrgLogin string
@@ -26,7 +59,7 @@ type ProjectItem struct {
 
 // Title returns the title for the list item
 func (i ProjectItem) Title() string {
-	return fmt.Sprintf("#%d: %s", i.Project.Number, i.Project.Title)
+	return fmt.Sprintf("#%d: %s", int(i.Project.Number), i.Project.Title)
 }
 
 // Description returns the description for the list item
@@ -35,7 +68,11 @@ func (i ProjectItem) Description() string {
 	if i.Project.Closed {
 		status = "Closed"
 	}
-	return fmt.Sprintf("Organization: %s • Status: %s", i.OrgLogin, status)
+	visibility := "Private"
+	if i.Project.Public {
+		visibility = "Public"
+	}
+	return fmt.Sprintf("Organization: %s • Status: %s • %s", i.OrgLogin, status, visibility)
 }
 
 // FilterValue returns the value to use for filtering
@@ -46,11 +83,18 @@ func (i ProjectItem) FilterValue() string {
 // ProjectsListViewModel is the model for the projects list view
 type ProjectsListViewModel struct {
 	list    list.Model
+	project *ProjectViewModel
 	spinner tui.Spinner
 	loading bool
 	err     error
 	width   int
 	height  int
+
+	// confirm asks before deleting or publishing the project confirming
+	confirm       *huh.Form
+	confirmAction string
+	confirming    models.ProjectsJson
+	confirmed     *bool
 }
 
 // NewProjectsListViewModel creates a new projects list view model
@@ -85,12 +129,10 @@ func (m ProjectsListViewModel) Init() tea.Cmd {
 
 // fetchProjects fetches projects from the GitHub API
 func (m ProjectsListViewModel) fetchProjects() tea.Msg {
-	return func() tea.Msg {
-		projects, err := ghc.GetProjects()
-		return projectsMsg{
-			projects: projects,
-			err:      err,
-		}
+	projects, err := ghc.GetProjects()
+	return projectsMsg{
+		projects: projects,
+		err:      err,
 	}
 }
 
@@ -98,6 +140,28 @@ func (m ProjectsListViewModel) fetchProjects() tea.Msg {
 func (m ProjectsListViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if _, ok := msg.(backMsg); ok {
+		m.project = nil
+		return m, nil
+	}
+
+	// Delegate to the open project
+	if m.project != nil {
+		if size, ok := msg.(tea.WindowSizeMsg); ok {
+			m.width = size.Width
+			m.height = size.Height
+			m.list.SetSize(size.Width, size.Height-4)
+		}
+		model, cmd := m.project.Update(msg)
+		project := model.(ProjectViewModel)
+		m.project = &project
+		return m, cmd
+	}
+
+	if m.confirm != nil {
+		return m.updateConfirm(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -105,10 +169,83 @@ func (m ProjectsListViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list.SetSize(msg.Width, msg.Height-4) // Leave space for header/footer
 
 	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
+		case "enter":
+			if item, ok := m.list.SelectedItem().(ProjectItem); ok {
+				return m.openProject(item)
+			}
+		case "c":
+			if item, ok := m.list.SelectedItem().(ProjectItem); ok {
+				state := "closed"
+				if item.Project.Closed {
+					state = "open"
+				}
+				return m, saveProject(item.Project, map[string]string{models.SettingState: state})
+			}
+		case "v":
+			if item, ok := m.list.SelectedItem().(ProjectItem); ok {
+				if item.Project.Public {
+					return m, saveProject(item.Project, map[string]string{models.SettingVisibility: "private"})
+				}
+				return m.askConfirm(confirmPublish, item.Project)
+			}
+		case "e":
+			if item, ok := m.list.SelectedItem().(ProjectItem); ok {
+				return m, m.editSettings(item.Project)
+			}
+		case "D":
+			if item, ok := m.list.SelectedItem().(ProjectItem); ok {
+				return m.askConfirm(confirmDelete, item.Project)
+			}
+		}
+
+	case settingsEditedMsg:
+		if msg.err != nil {
+			os.Remove(msg.path)
+			return m, m.list.NewStatusMessage("Editor failed: " + msg.err.Error())
+		}
+		values, err := ReadSettingsFile(msg.path, projectModel(msg.project))
+		if err != nil {
+			return m, m.list.NewStatusMessage("Not saved: " + err.Error())
+		}
+		return m, saveProject(msg.project, values)
+
+	case projectSavedMsg:
+		if msg.err != nil {
+			return m, m.list.NewStatusMessage("Saving failed: " + msg.err.Error())
+		}
+		if len(msg.changed) == 0 {
+			return m, m.list.NewStatusMessage("Nothing changed")
+		}
+		var cmd tea.Cmd
+		if i := m.itemIndex(msg.project.Id); i >= 0 {
+			item := m.list.Items()[i].(ProjectItem)
+			item.Project = msg.project
+			cmd = m.list.SetItem(i, item)
+		}
+		status := "Changed " + strings.Join(msg.changed, ", ") + " of " + msg.project.Title
+		if msg.journalErr != nil {
+			status += " (not recorded for undo)"
+		}
+		return m, tea.Batch(cmd, m.list.NewStatusMessage(status))
+
+	case projectDeletedMsg:
+		if msg.err != nil {
+			return m, m.list.NewStatusMessage("Deleting failed: " + msg.err.Error())
+		}
+		if i := m.itemIndex(msg.project.Id); i >= 0 {
+			m.list.RemoveItem(i)
+		}
+		status := "Deleted " + msg.project.Title
+		if msg.journalErr != nil {
+			status += " (not recorded)"
 		}
+		return m, m.list.NewStatusMessage(status)
 
 	case projectsMsg:
 		m.loading = false
@@ -146,11 +283,152 @@ func (m ProjectsListViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// itemIndex returns the index of the project with the given ID in the list, or -1
+func (m ProjectsListViewModel) itemIndex(id string) int {
+	for i, it := range m.list.Items() {
+		if item, ok := it.(ProjectItem); ok && item.Project.Id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// askConfirm shows the form confirming action on project
+func (m ProjectsListViewModel) askConfirm(action string, project models.ProjectsJson) (tea.Model, tea.Cmd) {
+	var field huh.Field
+	switch action {
+	case confirmDelete:
+		var typed string
+		field = huh.NewInput().
+			Title(fmt.Sprintf("Type %q to delete the project", project.Title)).
+			Description("Its items and fields are deleted too. This cannot be undone.").
+			Validate(func(s string) error {
+				if s != project.Title {
+					return errors.New("the title does not match")
+				}
+				return nil
+			}).
+			Value(&typed)
+	case confirmPublish:
+		m.confirmed = new(bool)
+		field = huh.NewConfirm().
+			Title(fmt.Sprintf("Make %q public?", project.Title)).
+			Description("Anyone on the internet will be able to see the project.").
+			Value(m.confirmed)
+	}
+	m.confirm = newEmbeddedForm(m.width, field)
+	m.confirmAction = action
+	m.confirming = project
+	return m, m.confirm.Init()
+}
+
+// updateConfirm passes msg to the open confirmation form and runs the
+// confirmed action once it completes
+func (m ProjectsListViewModel) updateConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = size.Width
+		m.height = size.Height
+		m.list.SetSize(size.Width, size.Height-4)
+	}
+	model, cmd := m.confirm.Update(msg)
+	if f, ok := model.(*huh.Form); ok {
+		m.confirm = f
+	}
+	switch m.confirm.State {
+	case huh.StateAborted:
+		m.confirm = nil
+		return m, m.list.NewStatusMessage("Cancelled")
+	case huh.StateCompleted:
+		m.confirm = nil
+		switch m.confirmAction {
+		case confirmDelete:
+			return m, deleteProject(m.confirming)
+		case confirmPublish:
+			if !*m.confirmed {
+				return m, m.list.NewStatusMessage("Cancelled")
+			}
+			return m, saveProject(m.confirming, map[string]string{models.SettingVisibility: "public"})
+		}
+	}
+	return m, cmd
+}
+
+// editSettings suspends the view and opens the title, description and readme
+// of project in the user's editor
+func (m ProjectsListViewModel) editSettings(project models.ProjectsJson) tea.Cmd {
+	path, err := WriteSettingsFile(projectModel(project))
+	if err != nil {
+		return m.list.NewStatusMessage("Cannot open the editor: " + err.Error())
+	}
+	return tea.ExecProcess(tui.EditorCommand(path), func(err error) tea.Msg {
+		return settingsEditedMsg{project: project, path: path, err: err}
+	})
+}
+
+// saveProject changes the settings of project to values
+func saveProject(project models.ProjectsJson, values map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		p := projectModel(project)
+		rec := journal.Begin("project list")
+		changed, err := SaveSettings(rec, p, values)
+		if err != nil {
+			return projectSavedMsg{project: project, err: err}
+		}
+		project.Title = p.Title
+		project.ShortDescription = p.ShortDescription
+		project.Readme = p.Readme
+		project.Public = p.Public
+		project.Closed = p.Closed
+		return projectSavedMsg{project: project, changed: changed, journalErr: rec.Err()}
+	}
+}
+
+// deleteProject deletes project from GitHub
+func deleteProject(project models.ProjectsJson) tea.Cmd {
+	return func() tea.Msg {
+		if err := ghc.DeleteProject(project.Owner.Login, int(project.Number)); err != nil {
+			return projectDeletedMsg{project: project, err: err}
+		}
+		rec := journal.Begin("project list")
+		rec.ProjectDeleted(project.Owner.Login, int(project.Number), project.Id)
+		return projectDeletedMsg{project: project, journalErr: rec.Err()}
+	}
+}
+
+// projectModel returns the settings of a listed project as a models.Project
+func projectModel(p models.ProjectsJson) *models.Project {
+	return &models.Project{
+		ID:               p.Id,
+		Number:           int(p.Number),
+		Owner:            p.Owner.Login,
+		Title:            p.Title,
+		ShortDescription: p.ShortDescription,
+		Readme:           p.Readme,
+		URL:              p.Url,
+		Closed:           p.Closed,
+		Public:           p.Public,
+	}
+}
+
+// openProject shows the board of the selected project
+func (m ProjectsListViewModel) openProject(item ProjectItem) (tea.Model, tea.Cmd) {
+	project := NewProjectViewModel(item.OrgLogin, int(item.Project.Number), ViewOptions{})
+	project.embedded = true
+	model, _ := project.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	project = model.(ProjectViewModel)
+	m.project = &project
+	return m, project.Init()
+}
+
 // View renders the model
 func (m ProjectsListViewModel) View() string {
+	if m.project != nil {
+		return m.project.View()
+	}
+
 	if m.err != nil {
 		return tui.Header("Error") + "\n\n" +
-			fmt.Sprintf("Error fetching projects: %v", m.err) + "\n\n" +
+			"Error fetching projects: " + clierr.Render(m.err) + "\n\n" +
 			tui.Footer("Press q to quit")
 	}
 
@@ -160,10 +438,16 @@ func (m ProjectsListViewModel) View() string {
 			tui.Footer("Press q to quit")
 	}
 
+	if m.confirm != nil {
+		return tui.Header("GitHub Projects") + "\n\n" +
+			m.confirm.View() + "\n\n" +
+			tui.Footer("Enter: Confirm • Esc: Cancel")
+	}
+
 	// Render the list with header and footer
 	return strings.Join([]string{
 		tui.Header("GitHub Projects"),
 		m.list.View(),
-		tui.Footer("↑/↓: Navigate • /: Filter • Enter: Select • q: Quit"),
+		tui.Footer("↑/↓: Navigate • /: Filter • Enter: Select • c: Close/Reopen • v: Visibility • e: Edit • D: Delete • q: Quit"),
 	}, "\n")
 }
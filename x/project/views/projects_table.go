@@ -0,0 +1,27 @@
+package views
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+// PrintProjects writes projects as a plain table for non-interactive output
+func PrintProjects(w io.Writer, projects []models.ProjectsJson) error {
+	t := tui.NewTablePrinter(w, "NUMBER", "TITLE", "OWNER", "STATUS", "URL")
+	for _, p := range projects {
+		status := "Open"
+		if p.Closed {
+			status = "Closed"
+		}
+		t.AddField(strconv.Itoa(int(p.Number)))
+		t.AddField(p.Title)
+		t.AddField(p.Owner.Login)
+		t.AddField(status)
+		t.AddField(p.Url)
+		t.EndRow()
+	}
+	return t.Render()
+}
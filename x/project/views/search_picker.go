@@ -0,0 +1,190 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+// searchLimit bounds the results shown by the search picker
+const searchLimit = 50
+
+// searchResultsMsg carries the results of a search started by picker
+type searchResultsMsg struct {
+	picker *searchPicker
+	query  string
+	items  []models.Item
+	err    error
+}
+
+// itemsAddedMsg reports items added to the project from the search picker
+type itemsAddedMsg struct {
+	results    []BulkResult
+	journalErr error
+}
+
+// searchPicker finds issues and pull requests with GitHub search and lets the
+// user choose the ones to add to the project. Results already in the project
+// are shown but cannot be chosen.
+type searchPicker struct {
+	input    textinput.Model
+	existing map[string]bool
+	query    string
+	results  []models.Item
+	chosen   map[string]bool
+	cursor   int
+	loading  bool
+	err      error
+	height   int
+}
+
+// newSearchPicker opens a picker for a project holding items
+func newSearchPicker(items []models.Item, height int) (*searchPicker, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = "Search: "
+	input.Placeholder = "repo:org/x is:open label:bug"
+	existing := make(map[string]bool, len(items))
+	for _, item := range items {
+		existing[item.ContentID] = true
+	}
+	p := &searchPicker{input: input, existing: existing, chosen: map[string]bool{}, height: height}
+	return p, p.input.Focus()
+}
+
+// Update handles keys and search results and reports whether picking finished
+func (p *searchPicker) Update(msg tea.Msg) (editorState, tea.Cmd) {
+	switch msg := msg.(type) {
+	case searchResultsMsg:
+		if msg.picker != p || msg.query != p.query {
+			return editorOpen, nil
+		}
+		p.loading = false
+		p.results, p.err = msg.items, msg.err
+		p.chosen = map[string]bool{}
+		p.cursor = 0
+		return editorOpen, nil
+
+	case tea.KeyMsg:
+		if p.input.Focused() {
+			switch msg.String() {
+			case "esc":
+				if len(p.results) > 0 {
+					p.input.Blur()
+					return editorOpen, nil
+				}
+				return editorCancelled, nil
+			case "enter":
+				query := strings.TrimSpace(p.input.Value())
+				if query == "" {
+					return editorOpen, nil
+				}
+				p.input.Blur()
+				p.query = query
+				p.loading = true
+				return editorOpen, p.search(query)
+			}
+			var cmd tea.Cmd
+			p.input, cmd = p.input.Update(msg)
+			return editorOpen, cmd
+		}
+
+		switch msg.String() {
+		case "esc":
+			return editorCancelled, nil
+		case "/":
+			return editorOpen, p.input.Focus()
+		case "up", "k":
+			p.cursor = max(p.cursor-1, 0)
+		case "down", "j":
+			p.cursor = min(p.cursor+1, max(len(p.results)-1, 0))
+		case " ":
+			if p.cursor < len(p.results) {
+				id := p.results[p.cursor].ContentID
+				if !p.existing[id] {
+					p.chosen[id] = !p.chosen[id]
+				}
+			}
+		case "a":
+			// Choose every result that can be added, or none if all are chosen
+			all := true
+			for _, r := range p.results {
+				if !p.existing[r.ContentID] && !p.chosen[r.ContentID] {
+					all = false
+				}
+			}
+			for _, r := range p.results {
+				p.chosen[r.ContentID] = !all && !p.existing[r.ContentID]
+			}
+		case "enter":
+			if len(p.Chosen()) > 0 {
+				return editorDone, nil
+			}
+		}
+	}
+	return editorOpen, nil
+}
+
+func (p *searchPicker) search(query string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := ghc.SearchContent(query, searchLimit)
+		return searchResultsMsg{picker: p, query: query, items: items, err: err}
+	}
+}
+
+// Chosen returns the results chosen to be added, in search order
+func (p *searchPicker) Chosen() []models.Item {
+	var chosen []models.Item
+	for _, r := range p.results {
+		if p.chosen[r.ContentID] {
+			chosen = append(chosen, r)
+		}
+	}
+	return chosen
+}
+
+// View renders the query, the results and the key help
+func (p *searchPicker) View() string {
+	lines := []string{p.input.View(), ""}
+	switch {
+	case p.loading:
+		lines = append(lines, "Searching...")
+	case p.err != nil:
+		lines = append(lines, "Search failed: "+clierr.Render(p.err))
+	case p.query != "" && len(p.results) == 0:
+		lines = append(lines, "No issues or pull requests found")
+	}
+
+	// Scroll to keep the cursor in the visible rows
+	rows := max(p.height-8, 3)
+	start := max(p.cursor-rows+1, 0)
+	for i := start; i < len(p.results) && i < start+rows; i++ {
+		r := p.results[i]
+		box := "[ ]"
+		switch {
+		case p.existing[r.ContentID]:
+			box = "[✓]"
+		case p.chosen[r.ContentID]:
+			box = "[x]"
+		}
+		line := fmt.Sprintf("%s %s %s", box, r.Ref(), r.Title)
+		if p.existing[r.ContentID] {
+			line = detailMutedStyle.Render(line + " (in project)")
+		}
+		if i == p.cursor && !p.input.Focused() {
+			line = detailSelectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	help := "Enter: Search • Esc: Cancel"
+	if !p.input.Focused() {
+		help = fmt.Sprintf("%d chosen • Space: Choose • a: All • /: Search • Enter: Add • Esc: Cancel", len(p.Chosen()))
+	}
+	return strings.Join(lines, "\n") + "\n\n" + tui.Footer(help)
+}
@@ -0,0 +1,300 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+const (
+	tableMaxColumnWidth = 30
+	tableMinTitleWidth  = 20
+	sortAscending       = "asc"
+	sortDescending      = "desc"
+)
+
+// TableModel renders project items as a spreadsheet with configurable columns,
+// sorting and grouping
+type TableModel struct {
+	project *models.Project
+	items   []models.Item
+	marked  map[string]bool
+	columns []string
+	sortBy  string
+	desc    bool
+	groupBy string
+	rowIDs  []string
+	table   table.Model
+	width   int
+	height  int
+
+	// Open picker for columns, sorting or grouping
+	picker     *huh.Form
+	pickerKind rune
+	pickList   *[]string
+	pickValue  *string
+	pickOrder  *string
+}
+
+// NewTableModel creates a table laid out according to opts
+func NewTableModel(opts ViewOptions) TableModel {
+	sortBy, desc := opts.sortSpec()
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = defaultColumns
+	}
+
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.Bold(true).BorderStyle(lipgloss.NormalBorder()).BorderBottom(true).BorderForeground(lipgloss.Color("240"))
+	styles.Selected = styles.Selected.Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#2D3142")).Bold(false)
+
+	return TableModel{
+		columns: columns,
+		sortBy:  sortBy,
+		desc:    desc,
+		groupBy: opts.Group,
+		table:   table.New(table.WithFocused(true), table.WithStyles(styles)),
+	}
+}
+
+// SetSize sets the area available to the table
+func (t *TableModel) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+	t.table.SetHeight(max(height-2, 1))
+	t.rebuild()
+}
+
+// SetItems replaces the rows, keeping the cursor on the same item when possible
+func (t *TableModel) SetItems(project *models.Project, items []models.Item) {
+	t.project = project
+	t.items = items
+	t.rebuild()
+}
+
+// SetMarked sets the items marked for a bulk edit, shown from the next SetItems
+func (t *TableModel) SetMarked(marked map[string]bool) {
+	t.marked = marked
+}
+
+// Selected returns the item under the cursor, if the cursor is not on a group header
+func (t TableModel) Selected() (models.Item, bool) {
+	c := t.table.Cursor()
+	if c < 0 || c >= len(t.rowIDs) || t.rowIDs[c] == "" {
+		return models.Item{}, false
+	}
+	for _, item := range t.items {
+		if item.ID == t.rowIDs[c] {
+			return item, true
+		}
+	}
+	return models.Item{}, false
+}
+
+// Options returns the current column, sort and group settings
+func (t TableModel) Options() ViewOptions {
+	opts := ViewOptions{Layout: LayoutTable, Columns: t.columns, Sort: t.sortBy, Group: t.groupBy}
+	if t.desc && t.sortBy != "" {
+		opts.Sort += ":" + sortDescending
+	}
+	return opts
+}
+
+// Picking reports whether a picker is open and should receive all keys
+func (t TableModel) Picking() bool {
+	return t.picker != nil
+}
+
+// rebuild recomputes rows and column widths from the items and settings
+func (t *TableModel) rebuild() {
+	if t.project == nil {
+		return
+	}
+	selected := ""
+	if c := t.table.Cursor(); c >= 0 && c < len(t.rowIDs) {
+		selected = t.rowIDs[c]
+	}
+
+	items := slices.Clone(t.items)
+	sortItems(t.project, items, t.sortBy, t.desc)
+
+	var rows []table.Row
+	var ids []string
+	addItems := func(items []models.Item) {
+		for _, item := range items {
+			row := make(table.Row, len(t.columns))
+			for i, c := range t.columns {
+				row[i] = columnValue(item, c)
+			}
+			if t.marked[item.ID] {
+				row[0] = markPrefix + row[0]
+			}
+			rows = append(rows, row)
+			ids = append(ids, item.ID)
+		}
+	}
+	if t.groupBy != "" {
+		for _, g := range groupItems(t.project, items, t.groupBy) {
+			header := make(table.Row, len(t.columns))
+			header[0] = fmt.Sprintf("▾ %s (%d)", g.name, len(g.items))
+			rows = append(rows, header)
+			ids = append(ids, "")
+			addItems(g.items)
+		}
+	} else {
+		addItems(items)
+	}
+
+	// Rows must be cleared before the columns change shape
+	t.table.SetRows(nil)
+	t.table.SetColumns(t.tableColumns(rows))
+	t.table.SetRows(rows)
+	t.rowIDs = ids
+	if i := slices.Index(ids, selected); i >= 0 {
+		t.table.SetCursor(i)
+	}
+}
+
+// tableColumns sizes every column to its content and gives the title the remaining width
+func (t TableModel) tableColumns(rows []table.Row) []table.Column {
+	cols := make([]table.Column, len(t.columns))
+	used := 0
+	for i, name := range t.columns {
+		title := name
+		if name == t.sortBy && t.desc {
+			title += " ↓"
+		} else if name == t.sortBy {
+			title += " ↑"
+		}
+		width := lipgloss.Width(title)
+		for _, r := range rows {
+			width = max(width, lipgloss.Width(r[i]))
+		}
+		cols[i] = table.Column{Title: title, Width: min(width, tableMaxColumnWidth)}
+		if name != ColumnTitle {
+			used += cols[i].Width
+		}
+		used += 2 // cell padding
+	}
+	if i := slices.Index(t.columns, ColumnTitle); i >= 0 {
+		cols[i].Width = max(t.width-used, tableMinTitleWidth)
+	}
+	return cols
+}
+
+// Update handles table navigation and the column, sort and group pickers
+func (t TableModel) Update(msg tea.Msg) (TableModel, tea.Cmd) {
+	if t.picker != nil {
+		return t.updatePicker(msg)
+	}
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "enter":
+			if item, ok := t.Selected(); ok {
+				return t, func() tea.Msg { return openItemMsg{itemID: item.ID} }
+			}
+			return t, nil
+		case "c", "s", "g":
+			cmd := t.openPicker(rune(key.String()[0]))
+			return t, cmd
+		case "S":
+			t.desc = !t.desc
+			t.rebuild()
+			return t, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	t.table, cmd = t.table.Update(msg)
+	return t, cmd
+}
+
+func (t *TableModel) openPicker(kind rune) tea.Cmd {
+	available := AvailableColumns(t.project)
+	options := func(none string) []huh.Option[string] {
+		opts := []huh.Option[string]{}
+		if none != "" {
+			opts = append(opts, huh.NewOption(none, ""))
+		}
+		for _, c := range available {
+			opts = append(opts, huh.NewOption(c, c))
+		}
+		return opts
+	}
+
+	t.pickerKind = kind
+	switch kind {
+	case 'c':
+		columns := slices.Clone(t.columns)
+		t.pickList = &columns
+		t.picker = newEmbeddedForm(t.width, huh.NewMultiSelect[string]().
+			Title("Visible columns").
+			Options(options("")...).
+			Value(t.pickList))
+	case 's':
+		sortBy, order := t.sortBy, sortAscending
+		if t.desc {
+			order = sortDescending
+		}
+		t.pickValue, t.pickOrder = &sortBy, &order
+		t.picker = newEmbeddedForm(t.width,
+			huh.NewSelect[string]().Title("Sort by").Options(options("(none)")...).Value(t.pickValue),
+			huh.NewSelect[string]().Title("Order").Options(
+				huh.NewOption("Ascending", sortAscending),
+				huh.NewOption("Descending", sortDescending),
+			).Value(t.pickOrder),
+		)
+	case 'g':
+		groupBy := t.groupBy
+		t.pickValue = &groupBy
+		t.picker = newEmbeddedForm(t.width, huh.NewSelect[string]().
+			Title("Group by").
+			Options(options("(none)")...).
+			Value(t.pickValue))
+	}
+	return t.picker.Init()
+}
+
+func (t TableModel) updatePicker(msg tea.Msg) (TableModel, tea.Cmd) {
+	model, cmd := t.picker.Update(msg)
+	if f, ok := model.(*huh.Form); ok {
+		t.picker = f
+	}
+	switch t.picker.State {
+	case huh.StateAborted:
+		t.picker = nil
+	case huh.StateCompleted:
+		switch t.pickerKind {
+		case 'c':
+			if len(*t.pickList) > 0 {
+				t.columns = *t.pickList
+			}
+		case 's':
+			t.sortBy = *t.pickValue
+			t.desc = *t.pickOrder == sortDescending
+		case 'g':
+			t.groupBy = *t.pickValue
+		}
+		t.picker = nil
+		t.rebuild()
+		return t, nil
+	}
+	return t, cmd
+}
+
+// View renders the table or the open picker
+func (t TableModel) View() string {
+	if t.picker != nil {
+		return t.picker.View()
+	}
+	if len(t.items) == 0 {
+		return "No items match"
+	}
+	return t.table.View()
+}
@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/report/views"
+	"github.com/spf13/cobra"
+)
+
+// CFDAction handles the 'report cfd' command
+func CFDAction(cmd *cobra.Command, args []string) {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	fieldName, _ := cmd.Flags().GetString("by")
+	asCSV, _ := cmd.Flags().GetBool("csv")
+	height, _ := cmd.Flags().GetInt("height")
+
+	since, err := (filter.Env{}).Time(sinceFlag)
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid --since: %w", err))
+	}
+
+	s, project, _, err := openProject(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	defer s.Close()
+
+	field := project.Field(fieldName)
+	if field == nil || field.Type != models.FieldTypeSingleSelect {
+		clierr.Exit(clierr.NotFoundf("project %q has no single select field named %q", project.Title, fieldName))
+	}
+	counts, err := s.DailyCounts(project.ID, field.Name, since)
+	if err != nil {
+		clierr.Exit(err)
+	}
+
+	series := views.NewSeries(field, counts, time.Now())
+	if asCSV {
+		if err := series.WriteCSV(os.Stdout); err != nil {
+			clierr.Exit(err)
+		}
+		return
+	}
+	fmt.Println(series.Render(tui.TerminalWidth(), height))
+}
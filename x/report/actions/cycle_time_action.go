@@ -0,0 +1,47 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/x/report/views"
+	sprintactions "github.com/prnk28/gh-pm/x/sprint/actions"
+	"github.com/spf13/cobra"
+)
+
+// CycleTimeAction handles the 'report cycle-time' command
+func CycleTimeAction(cmd *cobra.Command, args []string) {
+	start, _ := cmd.Flags().GetStringSlice("start")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	listItems, _ := cmd.Flags().GetBool("items")
+
+	since, err := (filter.Env{}).Time(sinceFlag)
+	if err != nil {
+		clierr.Exit(clierr.Invalidf("invalid --since: %w", err))
+	}
+	opts, err := sprintactions.Options(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+
+	s, project, items, err := openProject(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	defer s.Close()
+
+	times, err := s.CycleTimes(project.ID, models.StatusField, start, opts.Done, since)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	views.PrintCycleTimes(os.Stdout, times)
+	if listItems && len(times) > 0 {
+		fmt.Println()
+		if err := views.PrintCycleTimeItems(os.Stdout, times, items); err != nil {
+			clierr.Exit(err)
+		}
+	}
+}
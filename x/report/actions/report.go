@@ -0,0 +1,38 @@
+package actions
+
+import (
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// openProject opens the cache and reads the project named by the flags with all its items
+func openProject(cmd *cobra.Command) (*store.Store, *models.Project, []models.Item, error) {
+	number, _ := cmd.Flags().GetInt("project")
+	owner, _ := cmd.Flags().GetString("owner")
+
+	if !store.Exists() {
+		return nil, nil, nil, store.ErrNotCached
+	}
+	s, err := store.Open()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if owner == "" || owner == "@me" {
+		if owner, err = s.Meta(store.MetaViewer); err != nil {
+			s.Close()
+			return nil, nil, nil, err
+		}
+	}
+	project, err := s.Project(owner, number)
+	if err != nil {
+		s.Close()
+		return nil, nil, nil, err
+	}
+	items, err := s.Items(project.ID, "")
+	if err != nil {
+		s.Close()
+		return nil, nil, nil, err
+	}
+	return s, project, items, nil
+}
@@ -0,0 +1,40 @@
+package actions
+
+import (
+	"os"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/x/report/views"
+	sprintactions "github.com/prnk28/gh-pm/x/sprint/actions"
+	sprintviews "github.com/prnk28/gh-pm/x/sprint/views"
+	"github.com/spf13/cobra"
+)
+
+// VelocityAction handles the 'report velocity' command
+func VelocityAction(cmd *cobra.Command, args []string) {
+	last, _ := cmd.Flags().GetInt("last")
+
+	s, project, items, err := openProject(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	defer s.Close()
+
+	opts, err := sprintactions.Options(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	sprint, err := sprintviews.NewSprint(project, items, opts)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	transitions, err := s.Transitions(project.ID, models.StatusField)
+	if err != nil {
+		clierr.Exit(err)
+	}
+
+	rows := views.Velocity(sprint, transitions, time.Now(), last)
+	views.PrintVelocity(os.Stdout, rows, sprint.HasPoints())
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/x/report/actions"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	velocityCmd := &cobra.Command{
+		Use:   "velocity",
+		Short: "Show the items and points completed per iteration",
+		Long: `Show the items and points completed per iteration. An item counts for the
+iteration in which a sync first saw it move to a done status; items that were
+already done when first synced count for the iteration they are assigned to.`,
+		Run: actions.VelocityAction,
+	}
+	velocityCmd.Flags().Int("last", 6, "Number of iterations to show")
+
+	cycleTimeCmd := &cobra.Command{
+		Use:   "cycle-time",
+		Short: "Show how long items take from starting to done",
+		Long: `Show how long items take from entering a start status, In Progress by
+default, to a done status, based on the Status changes recorded by syncs.
+Changes between two syncs collapse into one, dated by the item's last update,
+and items already started when first synced are left out.`,
+		Run: actions.CycleTimeAction,
+	}
+	cycleTimeCmd.Flags().StringSlice("start", []string{"In Progress"}, "Status options that mark an item as started")
+	cycleTimeCmd.Flags().String("since", "90d", "Only include items finished since then, e.g. 30d or 2025-01-31")
+	cycleTimeCmd.Flags().Bool("items", false, "List the cycle time of every item")
+
+	cfdCmd := &cobra.Command{
+		Use:   "cfd",
+		Short: "Draw a cumulative flow diagram of items per Status",
+		Long: `Draw a cumulative flow diagram of the number of items per Status on each day,
+from the daily snapshots taken by 'gh pm project sync'. Use --csv to export the
+series for a spreadsheet instead.`,
+		Run: actions.CFDAction,
+	}
+	cfdCmd.Flags().String("since", "30d", "First day to show, e.g. 30d or 2025-01-31")
+	cfdCmd.Flags().String("by", models.StatusField, "Single select field to chart")
+	cfdCmd.Flags().Bool("csv", false, "Write the daily counts as CSV instead of drawing the chart")
+	cfdCmd.Flags().Int("height", 15, "Height of the chart in lines")
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report on project history recorded in the local cache",
+		Long: `Report on project history recorded in the local cache. Reports read the data
+saved by 'gh pm project sync', so sync regularly, e.g. daily, to record changes.`,
+	}
+	cmd.PersistentFlags().Int("project", 0, "Number of the project")
+	cmd.PersistentFlags().String("owner", "", "Login of the project owner (defaults to you)")
+	cmd.PersistentFlags().String("field", "", "Iteration field holding the sprints")
+	cmd.PersistentFlags().String("points", "", "Number field summed as story points")
+	cmd.PersistentFlags().StringSlice("done", nil, "Status options that count as finished (default Done)")
+	cmd.MarkPersistentFlagRequired("project")
+
+	cmd.AddCommand(velocityCmd, cycleTimeCmd, cfdCmd)
+	return cmd
+}
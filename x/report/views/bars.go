@@ -0,0 +1,57 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/pkg/text"
+)
+
+const barWidth = 40
+
+var barStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+type bar struct {
+	name  string
+	value float64
+	label string
+}
+
+// printBars writes a horizontal bar chart scaled to the largest value, using
+// eighth blocks for the fractional part of each bar
+func printBars(w io.Writer, bars []bar) {
+	var maxValue float64
+	nameWidth := 0
+	for _, b := range bars {
+		maxValue = max(maxValue, b.value)
+		nameWidth = max(nameWidth, text.DisplayWidth(b.name))
+	}
+	nameWidth = min(nameWidth, 24)
+
+	for _, b := range bars {
+		fill := ""
+		if maxValue > 0 {
+			fill = blocks(b.value / maxValue * barWidth)
+		}
+		name := text.Truncate(nameWidth, b.name)
+		fmt.Fprintf(w, "%s%s  %s %s\n", name, strings.Repeat(" ", nameWidth-text.DisplayWidth(name)), barStyle.Render(fill), b.label)
+	}
+}
+
+// blocks renders a bar of the given width in cells
+func blocks(width float64) string {
+	eighths := []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+	full := int(width)
+	return strings.Repeat("█", full) + eighths[int((width-float64(full))*8)]
+}
+
+// plural formats a count with a noun, adding an s unless the count is one
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
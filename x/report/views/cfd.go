@@ -0,0 +1,204 @@
+package views
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/store"
+)
+
+// NoValue labels items without a value for the charted field
+const NoValue = "No Status"
+
+// optionColors maps the colors of single select options to terminal colors
+var optionColors = map[string]string{
+	"GRAY":   "245",
+	"BLUE":   "33",
+	"GREEN":  "34",
+	"YELLOW": "220",
+	"ORANGE": "208",
+	"RED":    "160",
+	"PINK":   "205",
+	"PURPLE": "135",
+}
+
+// palette is used for values without an option color
+var palette = []string{"33", "34", "220", "208", "135", "160", "205", "245"}
+
+// Series is the number of items per field value on each day
+type Series struct {
+	Days []time.Time
+	// Values lists the field values in stacking order, bottom first
+	Values []string
+	// Counts holds the count of each value on each day, indexed [day][value]
+	Counts [][]int
+	colors []string
+}
+
+// NewSeries builds the daily series of a field from snapshot counts. Days
+// without a snapshot repeat the previous day, and the series starts at the
+// first snapshot. Values are stacked with the last option, usually Done, at
+// the bottom, followed by unknown values and items without a value.
+func NewSeries(field *models.Field, counts []store.DayCount, until time.Time) Series {
+	var s Series
+	for i := len(field.Options) - 1; i >= 0; i-- {
+		o := field.Options[i]
+		s.Values = append(s.Values, o.Name)
+		s.colors = append(s.colors, optionColors[o.Color])
+	}
+	for _, c := range counts {
+		if c.Value != "" && !slices.Contains(s.Values, c.Value) {
+			s.Values = append(s.Values, c.Value)
+			s.colors = append(s.colors, "")
+		}
+	}
+	s.Values = append(s.Values, "")
+	s.colors = append(s.colors, "240")
+	for i := range s.colors {
+		if s.colors[i] == "" {
+			s.colors[i] = palette[i%len(palette)]
+		}
+	}
+	if len(counts) == 0 {
+		return s
+	}
+
+	byDay := map[string][]int{}
+	for _, c := range counts {
+		key := c.Day.Format(time.DateOnly)
+		if byDay[key] == nil {
+			byDay[key] = make([]int, len(s.Values))
+		}
+		byDay[key][slices.Index(s.Values, c.Value)] += c.Count
+	}
+
+	first := counts[0].Day
+	last := time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC)
+	var previous []int
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		row, ok := byDay[day.Format(time.DateOnly)]
+		if !ok {
+			row = previous
+		}
+		s.Days = append(s.Days, day)
+		s.Counts = append(s.Counts, row)
+		previous = row
+	}
+	return s
+}
+
+// label returns the display name of a value
+func label(value string) string {
+	if value == "" {
+		return NoValue
+	}
+	return value
+}
+
+// WriteCSV writes the series with a row per day and a column per value
+func (s Series) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"date"}
+	for _, v := range s.Values {
+		header = append(header, label(v))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for d, day := range s.Days {
+		record := []string{day.Format(time.DateOnly)}
+		for _, n := range s.Counts[d] {
+			record = append(record, strconv.Itoa(n))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Render draws the series as a stacked area chart of the given size in cells,
+// followed by a date axis and a legend with the latest counts
+func (s Series) Render(width, height int) string {
+	if len(s.Days) == 0 {
+		return "No snapshots in this period, run 'gh pm project sync' daily to record them"
+	}
+
+	maxTotal := 1
+	for _, row := range s.Counts {
+		total := 0
+		for _, n := range row {
+			total += n
+		}
+		maxTotal = max(maxTotal, total)
+	}
+
+	axisWidth := len(strconv.Itoa(maxTotal)) + 1
+	plotWidth := max(width-axisWidth-1, 10)
+	// Stretch short series so every day gets the same number of columns
+	if len(s.Days) < plotWidth {
+		plotWidth = plotWidth / len(s.Days) * len(s.Days)
+	}
+
+	// top[c][v] is the row above the last cell of value v in column c
+	tops := make([][]int, plotWidth)
+	for c := range tops {
+		row := s.Counts[c*len(s.Days)/plotWidth]
+		cum := 0
+		tops[c] = make([]int, len(row))
+		for v, n := range row {
+			cum += n
+			tops[c][v] = int(math.Round(float64(cum) / float64(maxTotal) * float64(height)))
+		}
+	}
+
+	styles := make([]lipgloss.Style, len(s.Values))
+	for v, color := range s.colors {
+		styles[v] = lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	}
+
+	var sb strings.Builder
+	for r := height - 1; r >= 0; r-- {
+		axis := ""
+		switch r {
+		case height - 1:
+			axis = strconv.Itoa(maxTotal)
+		case 0:
+			axis = "0"
+		}
+		sb.WriteString(fmt.Sprintf("%*s ", axisWidth, axis))
+		for c := range tops {
+			cell := " "
+			for v, top := range tops[c] {
+				if r < top {
+					cell = styles[v].Render("█")
+					break
+				}
+			}
+			sb.WriteString(cell)
+		}
+		sb.WriteString("\n")
+	}
+
+	from, to := s.Days[0].Format("Jan 2"), s.Days[len(s.Days)-1].Format("Jan 2")
+	gap := max(plotWidth-len(from)-len(to), 1)
+	sb.WriteString(strings.Repeat(" ", axisWidth+1) + from + strings.Repeat(" ", gap) + to + "\n\n")
+
+	latest := s.Counts[len(s.Counts)-1]
+	for v := len(s.Values) - 1; v >= 0; v-- {
+		if s.Values[v] == "" && latest[v] == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s %s %d\n", styles[v].Render("█"), label(s.Values[v]), latest[v]))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
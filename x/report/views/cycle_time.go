@@ -0,0 +1,108 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/store"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+var cycleTimeBuckets = []struct {
+	name  string
+	limit time.Duration
+}{
+	{"< 1 day", 24 * time.Hour},
+	{"1-2 days", 2 * 24 * time.Hour},
+	{"2-4 days", 4 * 24 * time.Hour},
+	{"4-7 days", 7 * 24 * time.Hour},
+	{"1-2 weeks", 14 * 24 * time.Hour},
+	{"2-4 weeks", 28 * 24 * time.Hour},
+	{"4+ weeks", math.MaxInt64},
+}
+
+// Percentile returns the p-th percentile of sorted durations by linear interpolation
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(rank)
+	if lo+1 >= len(sorted) {
+		return sorted[lo]
+	}
+	return sorted[lo] + time.Duration((rank-float64(lo))*float64(sorted[lo+1]-sorted[lo]))
+}
+
+// FormatDuration formats a duration in days, or hours below a day
+func FormatDuration(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%.0fh", d.Hours())
+	}
+	return strconv.FormatFloat(d.Hours()/24, 'f', 1, 64) + "d"
+}
+
+// PrintCycleTimes writes the percentile distribution of cycle times and a histogram
+func PrintCycleTimes(w io.Writer, times []store.CycleTime) {
+	if len(times) == 0 {
+		fmt.Fprintln(w, "No items went from a start status to done in this period")
+		return
+	}
+	durations := make([]time.Duration, len(times))
+	for i, c := range times {
+		durations[i] = c.Duration()
+	}
+	slices.Sort(durations)
+
+	fmt.Fprintf(w, "Cycle time of %s\n\n", plural(len(durations), "item"))
+	for _, p := range []float64{50, 75, 85, 95} {
+		fmt.Fprintf(w, "  p%-3.0f %s\n", p, FormatDuration(Percentile(durations, p)))
+	}
+	fmt.Fprintf(w, "  max  %s\n\n", FormatDuration(durations[len(durations)-1]))
+
+	bars := make([]bar, len(cycleTimeBuckets))
+	for i, b := range cycleTimeBuckets {
+		bars[i].name = b.name
+	}
+	for _, d := range durations {
+		for i, b := range cycleTimeBuckets {
+			if d < b.limit {
+				bars[i].value++
+				break
+			}
+		}
+	}
+	for i := range bars {
+		bars[i].label = strconv.Itoa(int(bars[i].value))
+	}
+	printBars(w, bars)
+}
+
+// PrintCycleTimeItems writes the cycle time of each item, slowest first
+func PrintCycleTimeItems(w io.Writer, times []store.CycleTime, items []models.Item) error {
+	byID := map[string]models.Item{}
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	sorted := slices.Clone(times)
+	slices.SortFunc(sorted, func(a, b store.CycleTime) int {
+		return int(b.Duration() - a.Duration())
+	})
+
+	t := tui.NewTablePrinter(w, "ITEM", "TITLE", "STARTED", "DONE", "CYCLE TIME")
+	for _, c := range sorted {
+		item := byID[c.ItemID]
+		t.AddField(item.Ref())
+		t.AddField(item.Title)
+		t.AddField(c.Started.Format(time.DateOnly))
+		t.AddField(c.Finished.Format(time.DateOnly))
+		t.AddField(FormatDuration(c.Duration()))
+		t.EndRow()
+	}
+	return t.Render()
+}
@@ -0,0 +1,100 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/store"
+	sprintviews "github.com/prnk28/gh-pm/x/sprint/views"
+)
+
+// VelocityRow is the work completed in one iteration
+type VelocityRow struct {
+	Iteration string
+	Items     int
+	Points    float64
+}
+
+// Velocity counts the items and points completed in each of the last
+// iterations that have started by now. transitions are the recorded Status
+// changes, oldest first.
+func Velocity(sprint *sprintviews.Sprint, transitions []store.Transition, now time.Time, last int) []VelocityRow {
+	// When each item last moved to a done status, forgetting reopened items
+	doneAt := map[string]time.Time{}
+	for _, t := range transitions {
+		switch {
+		case t.Initial:
+		case sprint.IsDoneStatus(t.To):
+			doneAt[t.ItemID] = t.At
+		default:
+			delete(doneAt, t.ItemID)
+		}
+	}
+
+	byIteration := map[string]*VelocityRow{}
+	for _, it := range sprint.Field.Iterations {
+		byIteration[it.ID] = &VelocityRow{Iteration: it.Title}
+	}
+	for _, item := range sprint.Items {
+		if !sprint.IsDone(item) {
+			continue
+		}
+		// Items done between two iterations count for the one they are assigned to
+		id := item.Values[sprint.Field.Name].IterationID
+		if at, ok := doneAt[item.ID]; ok {
+			if it, found := sprint.Iteration(at, 0); found {
+				id = it.ID
+			}
+		}
+		if row, ok := byIteration[id]; ok {
+			row.Items++
+			row.Points += sprint.PointsOf(item)
+		}
+	}
+
+	var rows []VelocityRow
+	for _, it := range sprint.Field.Iterations {
+		if it.Start().After(now) {
+			break
+		}
+		rows = append(rows, *byIteration[it.ID])
+	}
+	if len(rows) > last {
+		rows = rows[len(rows)-last:]
+	}
+	return rows
+}
+
+// PrintVelocity writes the rows as a bar chart of points, or of items when points is false
+func PrintVelocity(w io.Writer, rows []VelocityRow, points bool) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No iterations have started yet")
+		return
+	}
+	value := func(r VelocityRow) float64 {
+		if points {
+			return r.Points
+		}
+		return float64(r.Items)
+	}
+
+	var bars []bar
+	var total float64
+	for _, r := range rows {
+		label := plural(r.Items, "item")
+		if points {
+			label = strconv.FormatFloat(r.Points, 'f', -1, 64) + " pts, " + label
+		}
+		bars = append(bars, bar{name: r.Iteration, value: value(r), label: label})
+		total += value(r)
+	}
+	printBars(w, bars)
+
+	unit := "items"
+	if points {
+		unit = "points"
+	}
+	fmt.Fprintf(w, "\nAverage: %s %s per iteration\n", strconv.FormatFloat(total/float64(len(rows)), 'f', 1, 64), unit)
+}
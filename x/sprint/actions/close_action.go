@@ -0,0 +1,74 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// CloseAction handles the 'sprint close' command
+func CloseAction(cmd *cobra.Command, args []string) {
+	sprint, err := loadSprint(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+
+	var closing models.Iteration
+	var ok bool
+	if title, _ := cmd.Flags().GetString("iteration"); title != "" {
+		closing, ok = sprint.Named(title)
+		if !ok {
+			clierr.Exit(clierr.NotFoundf("%s has no iteration named %q", sprint.Field.Name, title))
+		}
+	} else if closing, ok = sprint.Iteration(time.Now(), 0); !ok {
+		clierr.Exit(clierr.NotFoundf("no iteration of %s is running, pass --iteration", sprint.Field.Name))
+	}
+	next, ok := sprint.After(closing)
+	if !ok {
+		clierr.Exit(clierr.NotFoundf("%s has no iteration after %s, add one in the project settings", sprint.Field.Name, closing.Title))
+	}
+
+	completed, carried := sprint.Split(sprint.In(closing))
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		fmt.Printf("Dry run: closing %s would move %d items to %s\n", closing.Title, len(carried), next.Title)
+		for _, item := range carried {
+			fmt.Printf("  → %s %s\n", item.Ref(), item.Title)
+		}
+		return
+	}
+
+	// Failures do not stop the others, the failed items stay in the closed
+	// iteration and are listed after the summary
+	rec := journal.Begin("sprint close")
+	value := sprint.Value(next)
+	var moved, failed []models.Item
+	var errs []error
+	for _, item := range carried {
+		if err := ghc.SetItemField(sprint.Project.ID, item.ID, sprint.Field, value); err != nil {
+			failed = append(failed, item)
+			errs = append(errs, err)
+			continue
+		}
+		rec.Field(sprint.Project.ID, item, sprint.Field, item.Values[sprint.Field.Name], value)
+		moved = append(moved, item)
+	}
+	sprint.PrintSummary(os.Stdout, closing, next, completed, moved)
+	if len(failed) > 0 {
+		fmt.Printf("\nNot moved, still in %s:\n", closing.Title)
+		for i, item := range failed {
+			fmt.Printf("  ✗ %s %s: %v\n", item.Ref(), item.Title, errs[i])
+		}
+	}
+	if err := rec.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the moves were not recorded and cannot be undone: %v\n", err)
+	}
+	if len(failed) > 0 {
+		os.Exit(clierr.ExitError)
+	}
+}
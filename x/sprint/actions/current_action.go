@@ -0,0 +1,56 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/project/actions"
+	projectviews "github.com/prnk28/gh-pm/x/project/views"
+	"github.com/prnk28/gh-pm/x/sprint/views"
+	"github.com/spf13/cobra"
+)
+
+// CurrentAction handles the 'sprint current' command
+func CurrentAction(cmd *cobra.Command, args []string) {
+	showIteration(cmd, 0, "@current")
+}
+
+// NextAction handles the 'sprint next' command
+func NextAction(cmd *cobra.Command, args []string) {
+	showIteration(cmd, 1, "@next")
+}
+
+// showIteration shows the items of the iteration offset from the current one
+func showIteration(cmd *cobra.Command, offset int, token string) {
+	sprint, err := loadSprint(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	it, ok := sprint.Iteration(time.Now(), offset)
+	if !ok {
+		clierr.Exit(clierr.NotFoundf("%s has no %s iteration", sprint.Field.Name, token[1:]))
+	}
+	items := sprint.In(it)
+	done, _ := sprint.Split(items)
+	fmt.Fprintf(os.Stderr, "%s: %d of %d done\n", views.FormatIteration(it), len(done), len(items))
+
+	if !tui.IsInteractive(cmd) {
+		if err := projectviews.PrintItems(os.Stdout, sprint.Project, items, projectviews.ViewOptions{}, ""); err != nil {
+			clierr.Exit(err)
+		}
+		return
+	}
+	user, err := ghc.GetWhoami()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	// The board resolves the iteration token itself so reloading stays correct
+	actions.RunLoadedView(sprint.Project, sprint.Items, user.Login, projectviews.ViewOptions{
+		Layout: projectviews.LayoutBoard,
+		Filter: fmt.Sprintf("%q:%s", sprint.Field.Name, token),
+	})
+}
@@ -0,0 +1,33 @@
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/sprint/views"
+	"github.com/spf13/cobra"
+)
+
+// PlanAction handles the 'sprint plan' command
+func PlanAction(cmd *cobra.Command, args []string) {
+	if !tui.IsInteractive(cmd) {
+		clierr.Exit(clierr.Invalidf("sprint plan needs an interactive terminal"))
+	}
+	sprint, err := loadSprint(cmd)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	next, ok := sprint.Iteration(time.Now(), 1)
+	if !ok {
+		clierr.Exit(clierr.Invalidf("%s has no next iteration, add one in the project settings", sprint.Field.Name))
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	p := tea.NewProgram(views.NewPlanModel(sprint, next, dryRun), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		clierr.Exit(fmt.Errorf("running program: %w", err))
+	}
+}
@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"github.com/prnk28/gh-pm/internal/config"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/x/sprint/views"
+	"github.com/spf13/cobra"
+)
+
+// Options reads the --field, --points and --done flags, falling back to the
+// sprint section of the config file
+func Options(cmd *cobra.Command) (views.Options, error) {
+	c, err := config.Load()
+	if err != nil {
+		return views.Options{}, err
+	}
+	opts := views.Options{Field: c.Sprint.Field, Points: c.Sprint.Points, Done: c.Sprint.Done}
+	if cmd.Flags().Changed("field") {
+		opts.Field, _ = cmd.Flags().GetString("field")
+	}
+	if cmd.Flags().Changed("points") {
+		opts.Points, _ = cmd.Flags().GetString("points")
+	}
+	if cmd.Flags().Changed("done") {
+		opts.Done, _ = cmd.Flags().GetStringSlice("done")
+	}
+	if len(opts.Done) == 0 {
+		opts.Done = []string{"Done"}
+	}
+	return opts, nil
+}
+
+// loadSprint fetches the project named by the flags and resolves its sprint fields
+func loadSprint(cmd *cobra.Command) (*views.Sprint, error) {
+	number, _ := cmd.Flags().GetInt("project")
+	owner, _ := cmd.Flags().GetString("owner")
+
+	opts, err := Options(cmd)
+	if err != nil {
+		return nil, err
+	}
+	project, err := ghc.GetProject(owner, number)
+	if err != nil {
+		return nil, err
+	}
+	items, err := ghc.GetItems(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	return views.NewSprint(project, items, opts)
+}
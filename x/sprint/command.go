@@ -0,0 +1,55 @@
+package sprint
+
+import (
+	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/prnk28/gh-pm/x/sprint/actions"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	currentCmd := &cobra.Command{
+		Use:   "current",
+		Short: "Show the items of the current iteration",
+		Run:   actions.CurrentAction,
+	}
+	nextCmd := &cobra.Command{
+		Use:   "next",
+		Short: "Show the items of the next iteration",
+		Run:   actions.NextAction,
+	}
+	planCmd := &cobra.Command{
+		Use:         "plan",
+		Short:       "Move backlog items into the next iteration",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Run:         actions.PlanAction,
+	}
+	closeCmd := &cobra.Command{
+		Use:         "close",
+		Short:       "Close an iteration, moving unfinished items into the next one",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+		Run:         actions.CloseAction,
+	}
+	closeCmd.Flags().String("iteration", "", "Title of the iteration to close (defaults to the current one)")
+	closeCmd.Flags().Bool("dry-run", false, "List the items that would move without moving them")
+	planCmd.Flags().Bool("dry-run", false, "Move items around without saving the changes")
+
+	subCommands := []*cobra.Command{currentCmd, nextCmd, planCmd, closeCmd}
+
+	cmd := &cobra.Command{
+		Use:   "sprint",
+		Short: "Plan and close iterations of a project",
+		Long: `Plan and close iterations of a project. The iteration field, the number field
+counted as points and the statuses counting as done default to the sprint
+section of the config file.`,
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeReadProject},
+	}
+	cmd.PersistentFlags().Int("project", 0, "Number of the project")
+	cmd.PersistentFlags().String("owner", "", "Login of the project owner (defaults to you)")
+	cmd.PersistentFlags().String("field", "", "Iteration field holding the sprints")
+	cmd.PersistentFlags().String("points", "", "Number field summed as story points")
+	cmd.PersistentFlags().StringSlice("done", nil, "Status options that count as finished (default Done)")
+	cmd.MarkPersistentFlagRequired("project")
+
+	cmd.AddCommand(subCommands...)
+	return cmd
+}
@@ -0,0 +1,248 @@
+package views
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+)
+
+var (
+	planPaneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240")).Padding(0, 1)
+	planFocusedStyle  = planPaneStyle.BorderForeground(lipgloss.Color("205"))
+	planTitleStyle    = lipgloss.NewStyle().Bold(true)
+	planSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#2D3142"))
+	planMutedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+// Panes of the planning view
+const (
+	paneBacklog = iota
+	paneSprint
+)
+
+// movedMsg reports the result of moving an item; on error the item returns to
+// from with its previous value
+type movedMsg struct {
+	itemID   string
+	from     int
+	previous models.FieldValue
+	err      error
+}
+
+// PlanModel moves items between the backlog and an iteration
+type PlanModel struct {
+	sprint *Sprint
+	rec    *journal.Recorder
+	dryRun bool
+	target models.Iteration
+	panes  [2][]models.Item
+	cursor [2]int
+	focus  int
+	saving int
+	// pending holds the items whose move is being saved; they cannot move
+	// again until it is, so that the moves reach GitHub in order
+	pending map[string]bool
+	status  string
+	width   int
+	height  int
+}
+
+// NewPlanModel creates a planning view for the target iteration. In a dry run
+// items move between the panes without being saved.
+func NewPlanModel(sprint *Sprint, target models.Iteration, dryRun bool) PlanModel {
+	var rec *journal.Recorder
+	if !dryRun {
+		rec = journal.Begin("sprint plan")
+	}
+	return PlanModel{
+		sprint:  sprint,
+		rec:     rec,
+		dryRun:  dryRun,
+		target:  target,
+		panes:   [2][]models.Item{sprint.Backlog(), sprint.In(target)},
+		pending: map[string]bool{},
+	}
+}
+
+// Init initializes the model
+func (m PlanModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the model
+func (m PlanModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "q", "esc":
+			if m.saving > 0 {
+				m.status = "Waiting for changes to be saved..."
+				return m, nil
+			}
+			return m, tea.Quit
+		case "tab", "left", "right", "h", "l":
+			m.focus = 1 - m.focus
+		case "up", "k":
+			if m.cursor[m.focus] > 0 {
+				m.cursor[m.focus]--
+			}
+		case "down", "j":
+			if m.cursor[m.focus] < len(m.panes[m.focus])-1 {
+				m.cursor[m.focus]++
+			}
+		case " ", "enter":
+			cmd := m.move()
+			return m, cmd
+		}
+		return m, nil
+
+	case movedMsg:
+		m.saving--
+		delete(m.pending, msg.itemID)
+		if msg.err != nil {
+			// Put the item back where it came from
+			to := 1 - msg.from
+			if i := slices.IndexFunc(m.panes[to], func(item models.Item) bool { return item.ID == msg.itemID }); i >= 0 {
+				item := withValue(m.panes[to][i], m.sprint.Field.Name, msg.previous)
+				m.panes[to] = slices.Delete(m.panes[to], i, i+1)
+				m.panes[msg.from] = append(m.panes[msg.from], item)
+				m.clampCursors()
+			}
+			m.status = fmt.Sprintf("Could not move item: %v", msg.err)
+			return m, nil
+		}
+		if m.saving == 0 {
+			m.status = "All changes saved"
+			if err := m.rec.Err(); err != nil {
+				m.status += fmt.Sprintf(", but they cannot be undone: %v", err)
+			}
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+// move sends the selected item to the other pane and saves the change
+func (m *PlanModel) move() tea.Cmd {
+	from := m.focus
+	pane := m.panes[from]
+	if len(pane) == 0 {
+		return nil
+	}
+	i := m.cursor[from]
+	if m.pending[pane[i].ID] {
+		m.status = "Still saving the last move of this item..."
+		return nil
+	}
+
+	field := m.sprint.Field
+	value := models.FieldValue{}
+	if from == paneBacklog {
+		value = m.sprint.Value(m.target)
+	}
+	previous := pane[i].Values[field.Name]
+	item := withValue(pane[i], field.Name, value)
+	m.panes[from] = slices.Delete(slices.Clone(pane), i, i+1)
+	m.panes[1-from] = append(m.panes[1-from], item)
+	m.clampCursors()
+	if m.dryRun {
+		m.status = "Dry run, changes are not saved"
+		return nil
+	}
+	m.saving++
+	m.pending[item.ID] = true
+	m.status = "Saving..."
+
+	rec, projectID := m.rec, m.sprint.Project.ID
+	return func() tea.Msg {
+		err := ghc.SetItemField(projectID, item.ID, field, value)
+		if err == nil {
+			rec.Field(projectID, item, field, previous, value)
+		}
+		return movedMsg{itemID: item.ID, from: from, previous: previous, err: err}
+	}
+}
+
+// withValue returns a copy of item with the value of a field replaced, leaving
+// the values shared with the sprint untouched
+func withValue(item models.Item, field string, value models.FieldValue) models.Item {
+	item.Values = maps.Clone(item.Values)
+	item.SetValue(field, value)
+	return item
+}
+
+func (m *PlanModel) clampCursors() {
+	for p := range m.panes {
+		m.cursor[p] = max(0, min(m.cursor[p], len(m.panes[p])-1))
+	}
+}
+
+// View renders the model
+func (m PlanModel) View() string {
+	width := max((m.width-4)/2, 30)
+	height := max(m.height-6, 5)
+
+	titles := [2]string{"Backlog", FormatIteration(m.target)}
+	var panes []string
+	for p, items := range m.panes {
+		title := fmt.Sprintf("%s · %d", titles[p], len(items))
+		if m.sprint.HasPoints() {
+			title += " · " + strconv.FormatFloat(m.sprint.Points(items), 'f', -1, 64) + " pts"
+		}
+		lines := []string{planTitleStyle.Render(text.Truncate(width-4, title)), ""}
+
+		// Scroll so the cursor stays visible
+		rows := height - 2
+		start := max(0, m.cursor[p]-rows+1)
+		for i := start; i < len(items) && i < start+rows; i++ {
+			lines = append(lines, m.renderItem(items[i], width-4, p == m.focus && i == m.cursor[p]))
+		}
+		if len(items) == 0 {
+			lines = append(lines, planMutedStyle.Render("No items"))
+		}
+
+		style := planPaneStyle
+		if p == m.focus {
+			style = planFocusedStyle
+		}
+		panes = append(panes, style.Width(width).Height(height).Render(strings.Join(lines, "\n")))
+	}
+
+	return strings.Join([]string{
+		tui.Header(fmt.Sprintf("Plan %s: %s", m.target.Title, m.sprint.Project.Title)),
+		lipgloss.JoinHorizontal(lipgloss.Top, panes...),
+		m.status,
+		tui.Footer("Tab/←/→: Switch pane • ↑/↓: Navigate • Space/Enter: Move item • q: Quit"),
+	}, "\n")
+}
+
+func (m PlanModel) renderItem(item models.Item, width int, selected bool) string {
+	line := item.Ref() + " " + item.Title
+	if m.sprint.HasPoints() {
+		if p := item.Values[m.sprint.points]; !p.IsZero() {
+			line = "[" + p.Text + "] " + line
+		}
+	}
+	line = text.Truncate(width, line)
+	if selected {
+		return planSelectedStyle.Width(width).Render(line)
+	}
+	return line
+}
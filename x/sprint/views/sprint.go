@@ -0,0 +1,194 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/models"
+)
+
+// Options names the fields the sprint commands work with
+type Options struct {
+	// Field is the iteration field, the first iteration field of the project when empty
+	Field string
+	// Points is a number field summed as story points; empty disables points
+	Points string
+	// Done lists the Status options that count as finished
+	Done []string
+}
+
+// Sprint is a project seen through its iteration field
+type Sprint struct {
+	Project *models.Project
+	Items   []models.Item
+	Field   models.Field
+	points  string
+	done    []string
+}
+
+// NewSprint resolves the iteration and points fields named by opts
+func NewSprint(project *models.Project, items []models.Item, opts Options) (*Sprint, error) {
+	s := &Sprint{Project: project, Items: items, done: opts.Done}
+	if len(s.done) == 0 {
+		s.done = []string{"Done"}
+	}
+
+	for _, f := range project.Fields {
+		if f.Type == models.FieldTypeIteration && (opts.Field == "" || strings.EqualFold(f.Name, opts.Field)) {
+			s.Field = f
+			break
+		}
+	}
+	if s.Field.ID == "" {
+		if opts.Field != "" {
+			return nil, clierr.NotFoundf("project %q has no iteration field named %q", project.Title, opts.Field)
+		}
+		return nil, clierr.NotFoundf("project %q has no iteration field", project.Title)
+	}
+
+	if opts.Points != "" {
+		for _, f := range project.Fields {
+			if f.Type == models.FieldTypeNumber && strings.EqualFold(f.Name, opts.Points) {
+				s.points = f.Name
+			}
+		}
+		if s.points == "" {
+			return nil, clierr.NotFoundf("project %q has no number field named %q", project.Title, opts.Points)
+		}
+	}
+	return s, nil
+}
+
+// Iteration returns the iteration offset iterations away from the one running at now
+func (s *Sprint) Iteration(now time.Time, offset int) (models.Iteration, bool) {
+	return s.Field.IterationAt(now, offset)
+}
+
+// Named returns the iteration with the given title
+func (s *Sprint) Named(title string) (models.Iteration, bool) {
+	for _, it := range s.Field.Iterations {
+		if strings.EqualFold(it.Title, title) {
+			return it, true
+		}
+	}
+	return models.Iteration{}, false
+}
+
+// After returns the iteration following it
+func (s *Sprint) After(it models.Iteration) (models.Iteration, bool) {
+	i := slices.IndexFunc(s.Field.Iterations, func(o models.Iteration) bool { return o.ID == it.ID })
+	if i < 0 || i+1 >= len(s.Field.Iterations) {
+		return models.Iteration{}, false
+	}
+	return s.Field.Iterations[i+1], true
+}
+
+// In returns the unarchived items assigned to an iteration
+func (s *Sprint) In(it models.Iteration) []models.Item {
+	var items []models.Item
+	for _, item := range s.Items {
+		if !item.Archived && item.Values[s.Field.Name].IterationID == it.ID {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Backlog returns the unfinished, unarchived items without an iteration
+func (s *Sprint) Backlog() []models.Item {
+	var items []models.Item
+	for _, item := range s.Items {
+		if !item.Archived && !s.IsDone(item) && item.Value(s.Field.Name) == "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// IsDone reports whether an item is finished, either by its Status or because
+// its issue or pull request is closed
+func (s *Sprint) IsDone(item models.Item) bool {
+	return s.IsDoneStatus(item.Status()) || item.State == "CLOSED" || item.State == "MERGED"
+}
+
+// IsDoneStatus reports whether a Status option counts as finished
+func (s *Sprint) IsDoneStatus(status string) bool {
+	return slices.ContainsFunc(s.done, func(d string) bool { return strings.EqualFold(d, status) })
+}
+
+// HasPoints reports whether a points field is configured
+func (s *Sprint) HasPoints() bool {
+	return s.points != ""
+}
+
+// Points sums the points field over items
+func (s *Sprint) Points(items []models.Item) float64 {
+	var total float64
+	for _, item := range items {
+		total += s.PointsOf(item)
+	}
+	return total
+}
+
+// Value returns the iteration as a field value
+func (s *Sprint) Value(it models.Iteration) models.FieldValue {
+	return models.FieldValue{Text: it.Title, IterationID: it.ID}
+}
+
+// PointsOf returns the points of a single item
+func (s *Sprint) PointsOf(item models.Item) float64 {
+	return item.Values[s.points].Number
+}
+
+// Split divides items into finished and unfinished ones
+func (s *Sprint) Split(items []models.Item) (done, open []models.Item) {
+	for _, item := range items {
+		if s.IsDone(item) {
+			done = append(done, item)
+		} else {
+			open = append(open, item)
+		}
+	}
+	return done, open
+}
+
+// FormatIteration describes an iteration with its dates, e.g. "Sprint 4 (Oct 6 – Oct 19)"
+func FormatIteration(it models.Iteration) string {
+	return fmt.Sprintf("%s (%s – %s)", it.Title, it.Start().Format("Jan 2"), it.End().AddDate(0, 0, -1).Format("Jan 2"))
+}
+
+// count formats a number of items, followed by their points when configured
+func (s *Sprint) count(items []models.Item) string {
+	n := fmt.Sprintf("%d items", len(items))
+	if len(items) == 1 {
+		n = "1 item"
+	}
+	if s.HasPoints() {
+		n += ", " + strconv.FormatFloat(s.Points(items), 'f', -1, 64) + " points"
+	}
+	return n
+}
+
+// PrintSummary writes the outcome of closing an iteration
+func (s *Sprint) PrintSummary(w io.Writer, closed, next models.Iteration, completed, carried []models.Item) {
+	fmt.Fprintf(w, "Closed %s\n\n", FormatIteration(closed))
+	fmt.Fprintf(w, "Completed:    %s\n", s.count(completed))
+	fmt.Fprintf(w, "Carried over: %s → %s\n", s.count(carried), next.Title)
+	if total := len(completed) + len(carried); total > 0 {
+		fmt.Fprintf(w, "Completion:   %d%%", len(completed)*100/total)
+		if s.HasPoints() {
+			if p := s.Points(completed) + s.Points(carried); p > 0 {
+				fmt.Fprintf(w, " of items, %d%% of points", int(s.Points(completed)*100/p))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	for _, item := range carried {
+		fmt.Fprintf(w, "  → %s %s\n", item.Ref(), item.Title)
+	}
+}
@@ -0,0 +1,478 @@
+package undo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/journal"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// step reverts one journal entry
+type step struct {
+	batch    journal.Batch
+	entry    journal.Entry
+	conflict string
+	// title is the title of a created project, typed to confirm deleting it
+	title string
+	// irreversible is set for changes GitHub offers no way to revert
+	irreversible bool
+}
+
+func undoAction(cmd *cobra.Command, args []string) {
+	last, _ := cmd.Flags().GetInt("last")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+	yes, _ := cmd.Flags().GetBool("yes")
+	if last < 1 {
+		clierr.Exit(clierr.Invalidf("--last must be at least 1"))
+	}
+
+	batches, err := journal.Batches()
+	if err != nil {
+		clierr.Exit(fmt.Errorf("reading the journal: %w", err))
+	}
+	if len(batches) == 0 {
+		fmt.Println("Nothing to undo")
+		return
+	}
+	batches = batches[:min(last, len(batches))]
+
+	steps, err := plan(batches)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if err := printPlan(steps); err != nil {
+		clierr.Exit(err)
+	}
+	conflicts, irreversible := 0, 0
+	for _, s := range steps {
+		switch {
+		case s.irreversible:
+			irreversible++
+		case s.conflict != "":
+			conflicts++
+		}
+	}
+	fmt.Println()
+	if dryRun {
+		fmt.Printf("Dry run: %d changes would be reverted\n", len(steps)-irreversible-skipped(conflicts, force))
+		return
+	}
+
+	if !yes {
+		if !tui.IsInteractive(cmd) {
+			clierr.Exit(clierr.Invalidf("pass --yes to revert changes in non-interactive mode"))
+		}
+		confirmed := false
+		err := huh.NewConfirm().
+			Title(fmt.Sprintf("Revert %d changes?", len(steps)-irreversible-skipped(conflicts, force))).
+			Value(&confirmed).
+			Run()
+		if err != nil {
+			clierr.Exit(err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled")
+			return
+		}
+		// Deleting a project takes its fields and views with it, so each one
+		// is confirmed like project delete
+		for _, s := range steps {
+			if s.entry.Kind != journal.KindProjectCreate || s.irreversible || (s.conflict != "" && !force) {
+				continue
+			}
+			if err := confirmDelete(s); err != nil {
+				clierr.Exit(err)
+			}
+		}
+	}
+
+	// A batch is only marked undone once all of its changes were reverted, so
+	// failed and skipped ones can be retried
+	incomplete := map[string]bool{}
+	reverted, failed := 0, 0
+	for _, s := range steps {
+		if s.irreversible {
+			continue
+		}
+		if s.conflict != "" && !force {
+			incomplete[s.batch.ID] = true
+			continue
+		}
+		if err := revert(s.entry); err != nil {
+			clierr.Print(os.Stderr, fmt.Errorf("reverting %s: %w", describeTarget(s.entry), err))
+			incomplete[s.batch.ID] = true
+			failed++
+			continue
+		}
+		reverted++
+	}
+	for _, b := range batches {
+		if incomplete[b.ID] {
+			continue
+		}
+		if err := journal.MarkUndone(b.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not update the journal: %v\n", err)
+		}
+	}
+
+	fmt.Printf("%d reverted, %d failed, %d skipped\n", reverted, failed, irreversible+skipped(conflicts, force))
+	if conflicts > 0 && !force {
+		fmt.Println("Items changed since were skipped, pass --force to revert them anyway")
+	}
+	if failed > 0 {
+		os.Exit(clierr.ExitError)
+	}
+}
+
+// confirmDelete asks for the title of the project created in s before it is
+// deleted
+func confirmDelete(s step) error {
+	var typed string
+	return huh.NewInput().
+		Title(fmt.Sprintf("Type %q to delete %s", s.title, describeTarget(s.entry))).
+		Description("Its fields and views are deleted too. This cannot be undone.").
+		Validate(func(v string) error {
+			if v != s.title {
+				return errors.New("the title does not match")
+			}
+			return nil
+		}).
+		Value(&typed).
+		Run()
+}
+
+func skipped(conflicts int, force bool) int {
+	if force {
+		return 0
+	}
+	return conflicts
+}
+
+// plan lists the entries of batches in the order they are reverted, newest
+// first, and flags the items and projects that changed since. Each step is
+// checked against the state left by reverting the newer ones, so that an item
+// changed several times is walked back to its oldest value. A created project
+// is only deleted once it is empty and every later change to it is reverted.
+func plan(batches []journal.Batch) ([]step, error) {
+	items := map[string]map[string]models.Item{}
+	projects := map[string]*models.Project{}
+	loadItems := func(projectID string) error {
+		if items[projectID] != nil {
+			return nil
+		}
+		list, err := ghc.GetItems(projectID)
+		if err != nil {
+			return fmt.Errorf("loading the items of %s: %w", projectID, err)
+		}
+		byID := map[string]models.Item{}
+		for _, item := range list {
+			byID[item.ID] = item
+		}
+		items[projectID] = byID
+		return nil
+	}
+	for _, b := range batches {
+		for _, e := range b.Entries {
+			switch e.Kind {
+			case journal.KindProjectDelete, journal.KindFieldDelete, journal.KindDraftConvert:
+			case journal.KindField, journal.KindLabels, journal.KindItemAdd, journal.KindDraftEdit, journal.KindItemArchive, journal.KindItemUnarchive:
+				if err := loadItems(e.ProjectID); err != nil {
+					return nil, err
+				}
+			case journal.KindProjectCreate:
+				if projects[e.ProjectID] == nil {
+					p, err := ghc.GetProject(e.Owner, e.Number)
+					if errors.Is(err, ghc.ErrNotFound) {
+						// Deleted since, which the step reports
+						continue
+					}
+					if err != nil {
+						return nil, fmt.Errorf("loading project %s/%d: %w", e.Owner, e.Number, err)
+					}
+					projects[e.ProjectID] = p
+				}
+				if err := loadItems(e.ProjectID); err != nil {
+					return nil, err
+				}
+			default:
+				if projects[e.ProjectID] != nil {
+					continue
+				}
+				p, err := ghc.GetProject(e.Owner, e.Number)
+				if err != nil {
+					return nil, fmt.Errorf("loading project %s/%d: %w", e.Owner, e.Number, err)
+				}
+				projects[e.ProjectID] = p
+			}
+		}
+	}
+
+	var steps []step
+	// kept counts the changes to each project that are left in place
+	kept := map[string]int{}
+	for _, b := range batches {
+		entries := slices.Clone(b.Entries)
+		slices.Reverse(entries)
+		for _, e := range entries {
+			s := step{batch: b, entry: e}
+			switch e.Kind {
+			case journal.KindField, journal.KindLabels, journal.KindItemAdd, journal.KindDraftEdit, journal.KindItemArchive, journal.KindItemUnarchive:
+				item, ok := items[e.ProjectID][e.Item.ID]
+				switch {
+				case !ok:
+					s.conflict = "item no longer in the project"
+				case e.Kind == journal.KindField && !item.Values[e.Field.Name].Same(*e.After):
+					s.conflict = "changed since to " + display(item.Values[e.Field.Name])
+				case e.Kind == journal.KindLabels && !hasLabels(item, e.AddedLabels, e.RemovedLabels):
+					s.conflict = "labels changed since"
+				case e.Kind == journal.KindDraftEdit && draftSetting(item, e.Setting) != e.To:
+					s.conflict = e.Setting + " changed since"
+				case e.Kind == journal.KindItemArchive && !item.Archived:
+					s.conflict = "restored since"
+				case e.Kind == journal.KindItemUnarchive && item.Archived:
+					s.conflict = "archived since"
+				}
+			case journal.KindProjectEdit:
+				if projects[e.ProjectID].Setting(e.Setting) != e.To {
+					s.conflict = e.Setting + " changed since"
+				}
+			case journal.KindFieldCreate, journal.KindFieldUpdate:
+				if !hasField(projects[e.ProjectID], e.Field.ID) {
+					s.conflict = "field no longer exists"
+				}
+			case journal.KindProjectCreate:
+				p := projects[e.ProjectID]
+				switch {
+				case p == nil:
+					s.conflict = "project no longer exists"
+					s.irreversible = true
+				case len(items[e.ProjectID]) > 0:
+					s.conflict = fmt.Sprintf("project has %d items", len(items[e.ProjectID]))
+				case kept[e.ProjectID] > 0:
+					s.conflict = "later changes are not reverted"
+				}
+				if p != nil {
+					s.title = p.Title
+				}
+			case journal.KindFieldDelete:
+				s.conflict = "deleted fields cannot be restored"
+				s.irreversible = true
+			case journal.KindProjectDelete:
+				s.conflict = "deleted projects cannot be restored"
+				s.irreversible = true
+			case journal.KindDraftConvert:
+				s.conflict = "issues cannot be turned back into drafts"
+				s.irreversible = true
+			}
+			if s.conflict == "" {
+				simulate(items, projects, e)
+			} else if !s.irreversible {
+				kept[e.ProjectID]++
+			}
+			steps = append(steps, s)
+		}
+	}
+	return steps, nil
+}
+
+// simulate applies the revert of e to the loaded items and projects, giving
+// the state the older entries are checked against
+func simulate(items map[string]map[string]models.Item, projects map[string]*models.Project, e journal.Entry) {
+	switch e.Kind {
+	case journal.KindField, journal.KindLabels, journal.KindDraftEdit, journal.KindItemArchive, journal.KindItemUnarchive:
+		item := items[e.ProjectID][e.Item.ID]
+		switch e.Kind {
+		case journal.KindField:
+			item.SetValue(e.Field.Name, *e.Before)
+		case journal.KindLabels:
+			item.Labels = slices.DeleteFunc(slices.Clone(item.Labels), func(l string) bool { return slices.Contains(e.AddedLabels, l) })
+			for _, l := range e.RemovedLabels {
+				if !slices.Contains(item.Labels, l) {
+					item.Labels = append(item.Labels, l)
+				}
+			}
+		case journal.KindDraftEdit:
+			if e.Setting == "body" {
+				item.Body = e.From
+			} else {
+				item.Title = e.From
+			}
+		case journal.KindItemArchive:
+			item.Archived = false
+		case journal.KindItemUnarchive:
+			item.Archived = true
+		}
+		items[e.ProjectID][e.Item.ID] = item
+	case journal.KindItemAdd:
+		delete(items[e.ProjectID], e.Item.ID)
+	case journal.KindProjectEdit:
+		projects[e.ProjectID].SetSetting(e.Setting, e.From)
+	case journal.KindFieldCreate:
+		p := projects[e.ProjectID]
+		p.Fields = slices.DeleteFunc(slices.Clone(p.Fields), func(f models.Field) bool { return f.ID == e.Field.ID })
+	}
+}
+
+// draftSetting returns the title or body of a draft item
+func draftSetting(item models.Item, setting string) string {
+	if setting == "body" {
+		return item.Body
+	}
+	return item.Title
+}
+
+func hasField(p *models.Project, id string) bool {
+	return slices.ContainsFunc(p.Fields, func(f models.Field) bool { return f.ID == id })
+}
+
+// hasLabels reports whether item still carries the added labels and lacks the removed ones
+func hasLabels(item models.Item, added, removed []string) bool {
+	for _, l := range added {
+		if !slices.Contains(item.Labels, l) {
+			return false
+		}
+	}
+	for _, l := range removed {
+		if slices.Contains(item.Labels, l) {
+			return false
+		}
+	}
+	return true
+}
+
+// revert undoes a single entry through the API
+func revert(e journal.Entry) error {
+	switch e.Kind {
+	case journal.KindField:
+		return ghc.SetItemField(e.ProjectID, e.Item.ID, *e.Field, *e.Before)
+	case journal.KindLabels:
+		return ghc.EditLabels(e.Item, e.RemovedLabels, e.AddedLabels)
+	case journal.KindItemAdd:
+		return ghc.DeleteItem(e.ProjectID, e.Item.ID)
+	case journal.KindDraftEdit:
+		if e.Setting == "body" {
+			return ghc.UpdateDraft(e.Item.ContentID, nil, &e.From)
+		}
+		return ghc.UpdateDraft(e.Item.ContentID, &e.From, nil)
+	case journal.KindItemArchive, journal.KindItemUnarchive:
+		return ghc.SetItemArchived(e.ProjectID, e.Item.ID, e.Kind == journal.KindItemUnarchive)
+	case journal.KindProjectCreate:
+		return ghc.DeleteProject(e.Owner, e.Number)
+	case journal.KindProjectEdit:
+		var edit ghc.ProjectEdit
+		if err := edit.Set(e.Setting, e.From); err != nil {
+			return err
+		}
+		return ghc.UpdateProject(e.ProjectID, edit)
+	case journal.KindFieldCreate:
+		return ghc.DeleteField(e.Field.ID)
+	case journal.KindFieldUpdate:
+		_, err := ghc.UpdateField(*e.OldField)
+		return err
+	}
+	return fmt.Errorf("unknown change %q", e.Kind)
+}
+
+func printPlan(steps []step) error {
+	t := tui.NewTablePrinter(os.Stdout, "WHEN", "COMMAND", "TARGET", "REVERT", "NOTE")
+	for _, s := range steps {
+		t.AddField(s.entry.At.Local().Format("Jan 2 15:04"))
+		t.AddField(s.batch.Command)
+		t.AddField(describeTarget(s.entry))
+		t.AddField(describeRevert(s.entry))
+		t.AddField(s.conflict)
+		t.EndRow()
+	}
+	return t.Render()
+}
+
+func describeTarget(e journal.Entry) string {
+	switch e.Kind {
+	case journal.KindField, journal.KindLabels, journal.KindItemAdd, journal.KindDraftEdit, journal.KindDraftConvert, journal.KindItemArchive, journal.KindItemUnarchive:
+		return e.Item.Ref() + " " + e.Item.Title
+	}
+	owner := e.Owner
+	if owner == "" {
+		owner = "@me"
+	}
+	return fmt.Sprintf("project %s/%d", owner, e.Number)
+}
+
+func describeRevert(e journal.Entry) string {
+	switch e.Kind {
+	case journal.KindField:
+		return fmt.Sprintf("%s: %s → %s", e.Field.Name, display(*e.After), display(*e.Before))
+	case journal.KindLabels:
+		var parts []string
+		for _, l := range e.AddedLabels {
+			parts = append(parts, "-"+l)
+		}
+		for _, l := range e.RemovedLabels {
+			parts = append(parts, "+"+l)
+		}
+		return "labels " + strings.Join(parts, ", ")
+	case journal.KindItemAdd:
+		return "remove item"
+	case journal.KindDraftEdit:
+		return fmt.Sprintf("%s: %s → %s", e.Setting, summarize(e.To), summarize(e.From))
+	case journal.KindDraftConvert:
+		return "restore draft"
+	case journal.KindItemArchive:
+		return "restore item"
+	case journal.KindItemUnarchive:
+		return "archive item"
+	case journal.KindProjectCreate:
+		return "delete project"
+	case journal.KindProjectEdit:
+		return fmt.Sprintf("%s: %s → %s", e.Setting, summarize(e.To), summarize(e.From))
+	case journal.KindFieldCreate:
+		return "delete field " + e.Field.Name
+	case journal.KindFieldUpdate:
+		return fmt.Sprintf("restore field %s (%s)", e.OldField.Name, optionNames(*e.OldField))
+	case journal.KindFieldDelete:
+		return "restore field " + e.Field.Name
+	case journal.KindProjectDelete:
+		return "restore project"
+	}
+	return e.Kind
+}
+
+func display(v models.FieldValue) string {
+	if v.IsZero() {
+		return "—"
+	}
+	return v.Text
+}
+
+// summarize shortens a setting value such as a readme to its first line
+func summarize(s string) string {
+	if s == "" {
+		return "—"
+	}
+	first, _, more := strings.Cut(s, "\n")
+	if more {
+		first += " …"
+	}
+	return first
+}
+
+func optionNames(f models.Field) string {
+	names := make([]string, 0, len(f.Options))
+	for _, o := range f.Options {
+		names = append(names, o.Name)
+	}
+	if len(names) == 0 {
+		return strings.ToLower(string(f.Type))
+	}
+	return strings.Join(names, ", ")
+}
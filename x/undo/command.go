@@ -0,0 +1,30 @@
+package undo
+
+import (
+	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Revert the latest changes made with gh pm",
+		Long: `Revert the latest changes made with gh pm. Every command and view that changes
+a project records what it changed in a local journal; undo sets the fields and
+labels back to their previous values and deletes created projects.
+
+Items changed by someone else since are left alone unless --force is passed,
+and so are created projects that still have items or later changes. Deleting
+a project asks for its title, like 'gh pm project delete'.`,
+		Example: `  gh pm undo --dry-run
+  gh pm undo --last 3`,
+		Args:        cobra.NoArgs,
+		Run:         undoAction,
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeProject},
+	}
+	cmd.Flags().Int("last", 1, "Number of recorded commands to revert, newest first")
+	cmd.Flags().Bool("dry-run", false, "List the changes that would be reverted without reverting them")
+	cmd.Flags().Bool("force", false, "Revert items even if they changed since")
+	cmd.Flags().BoolP("yes", "y", false, "Revert without asking for confirmation")
+	return cmd
+}
@@ -0,0 +1,203 @@
+package view
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/prnk28/gh-pm/internal/clierr"
+	"github.com/prnk28/gh-pm/internal/config"
+	"github.com/prnk28/gh-pm/internal/filter"
+	"github.com/prnk28/gh-pm/internal/ghc"
+	"github.com/prnk28/gh-pm/internal/models"
+	"github.com/prnk28/gh-pm/internal/tui"
+	"github.com/prnk28/gh-pm/x/project/actions"
+	"github.com/prnk28/gh-pm/x/project/views"
+	"github.com/spf13/cobra"
+)
+
+func saveAction(cmd *cobra.Command, args []string) {
+	v := config.View{Name: args[0]}
+	v.Project, _ = cmd.Flags().GetInt("project")
+	v.Owner, _ = cmd.Flags().GetString("owner")
+	v.Layout, _ = cmd.Flags().GetString("layout")
+	v.Columns, _ = cmd.Flags().GetStringSlice("columns")
+	v.Sort, _ = cmd.Flags().GetString("sort")
+	v.Group, _ = cmd.Flags().GetString("group")
+	v.Filter, _ = cmd.Flags().GetString("filter")
+
+	if v.Layout != views.LayoutBoard && v.Layout != views.LayoutTable {
+		clierr.Exit(clierr.Invalidf("invalid layout %q, expected board or table", v.Layout))
+	}
+	if _, err := filter.Parse(v.Filter); err != nil {
+		clierr.Exit(clierr.Invalidf("invalid filter: %w", err))
+	}
+	v, err := checkOptions(v)
+	if err != nil {
+		clierr.Exit(err)
+	}
+
+	c, err := config.Load()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	c.SetView(v)
+	if err := c.Save(); err != nil {
+		clierr.Exit(err)
+	}
+	fmt.Printf("Saved view %q, open it with: gh pm view open %s\n", v.Name, v.Name)
+}
+
+// checkOptions spells the columns, sort and group of v as the project names
+// them. It only fetches the project when one is not a built-in column.
+func checkOptions(v config.View) (config.View, error) {
+	opts := views.ViewOptions{Columns: v.Columns, Sort: v.Sort, Group: v.Group}
+	checked, err := opts.Check(&models.Project{})
+	if err != nil {
+		project, err := ghc.GetProject(v.Owner, v.Project)
+		if err != nil {
+			return v, err
+		}
+		if checked, err = opts.Check(project); err != nil {
+			return v, err
+		}
+	}
+	v.Columns, v.Sort, v.Group = checked.Columns, checked.Sort, checked.Group
+	return v, nil
+}
+
+func openAction(cmd *cobra.Command, args []string) {
+	c, err := config.Load()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	v, ok := c.View(args[0])
+	if !ok {
+		clierr.Exit(clierr.NotFoundf("no saved view named %q, see gh pm view list", args[0]))
+	}
+	cached, _ := cmd.Flags().GetBool("cached")
+	actions.RunView(cmd, v.Owner, v.Project, views.ViewOptions{
+		Layout:  v.Layout,
+		Columns: v.Columns,
+		Sort:    v.Sort,
+		Group:   v.Group,
+		Filter:  v.Filter,
+		Cached:  cached,
+	})
+}
+
+func listAction(cmd *cobra.Command, args []string) {
+	c, err := config.Load()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if len(c.Views) == 0 {
+		fmt.Println("No saved views, create one with gh pm view save")
+		return
+	}
+	t := tui.NewTablePrinter(os.Stdout, "NAME", "PROJECT", "LAYOUT", "FILTER")
+	for _, v := range c.Views {
+		owner := v.Owner
+		if owner == "" {
+			owner = "@me"
+		}
+		t.AddField(v.Name)
+		t.AddField(owner + "/" + strconv.Itoa(v.Project))
+		t.AddField(v.Layout)
+		t.AddField(v.Filter)
+		t.EndRow()
+	}
+	if err := t.Render(); err != nil {
+		clierr.Exit(err)
+	}
+}
+
+func deleteAction(cmd *cobra.Command, args []string) {
+	c, err := config.Load()
+	if err != nil {
+		clierr.Exit(err)
+	}
+	if !c.DeleteView(args[0]) {
+		clierr.Exit(clierr.NotFoundf("no saved view named %q", args[0]))
+	}
+	if err := c.Save(); err != nil {
+		clierr.Exit(err)
+	}
+	fmt.Printf("Deleted view %q\n", args[0])
+}
+
+func importAction(cmd *cobra.Command, args []string) {
+	number, _ := cmd.Flags().GetInt("project")
+	owner, _ := cmd.Flags().GetString("owner")
+	prefix, _ := cmd.Flags().GetString("prefix")
+
+	project, err := ghc.GetProject(owner, number)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	remote, err := ghc.GetProjectViews(owner, number)
+	if err != nil {
+		clierr.Exit(err)
+	}
+	c, err := config.Load()
+	if err != nil {
+		clierr.Exit(err)
+	}
+
+	for _, pv := range remote {
+		v := fromProjectView(project, pv)
+		v.Name = prefix + v.Name
+		v.Owner = owner
+		if _, err := filter.Parse(v.Filter); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: leaving out the filter of %q (%s): %v\n", v.Name, v.Filter, err)
+			v.Filter = ""
+		}
+		c.SetView(v)
+		fmt.Printf("Imported view %q\n", v.Name)
+	}
+	if err := c.Save(); err != nil {
+		clierr.Exit(err)
+	}
+}
+
+// fromProjectView converts a view saved on GitHub into a local view, keeping
+// the visible fields, sorting and grouping that have a matching column
+func fromProjectView(project *models.Project, pv models.ProjectView) config.View {
+	v := config.View{
+		Name:    strings.ReplaceAll(strings.ToLower(strings.TrimSpace(pv.Name)), " ", "-"),
+		Project: project.Number,
+		Layout:  views.LayoutTable,
+		Filter:  pv.Filter,
+	}
+	if pv.Layout == "BOARD_LAYOUT" {
+		v.Layout = views.LayoutBoard
+	}
+
+	available := views.AvailableColumns(project)
+	column := func(name string) string {
+		for _, c := range available {
+			if strings.EqualFold(c, name) {
+				return c
+			}
+		}
+		return ""
+	}
+	for _, f := range pv.Fields {
+		if c := column(f); c != "" {
+			v.Columns = append(v.Columns, c)
+		}
+	}
+	if len(pv.SortBy) > 0 {
+		if c := column(pv.SortBy[0].Field); c != "" {
+			v.Sort = c
+			if pv.SortBy[0].Direction == "DESC" {
+				v.Sort += ":desc"
+			}
+		}
+	}
+	if len(pv.GroupBy) > 0 {
+		v.Group = column(pv.GroupBy[0])
+	}
+	return v
+}
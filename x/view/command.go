@@ -0,0 +1,70 @@
+package view
+
+import (
+	"github.com/prnk28/gh-pm/internal/ctx"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	saveCmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save a project layout under a name",
+		Args:  cobra.ExactArgs(1),
+		Run:   saveAction,
+	}
+	saveCmd.Flags().Int("project", 0, "Number of the project the view opens")
+	saveCmd.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	saveCmd.Flags().String("layout", "board", "Layout to open: board or table")
+	saveCmd.Flags().StringSlice("columns", nil, "Table columns to show, e.g. Title,Status,Priority")
+	saveCmd.Flags().String("sort", "", "Column to sort by, append :desc for descending order")
+	saveCmd.Flags().String("group", "", "Column to group table rows by")
+	saveCmd.Flags().String("filter", "", `Only show items matching a query, e.g. 'iteration:@current assignee:@me'`)
+	saveCmd.MarkFlagRequired("project")
+
+	openCmd := &cobra.Command{
+		Use:         "open <name>",
+		Short:       "Open a saved view",
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeReadProject},
+		Args:        cobra.ExactArgs(1),
+		Run:         openAction,
+	}
+	openCmd.Flags().Bool("cached", false, "Read the project from the local cache filled by 'project sync'")
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Save the views of a project on GitHub as local views",
+		Long: `Save the views of a project on GitHub as local views. Filters that use
+syntax gh pm does not understand are left out and reported.`,
+		Annotations: map[string]string{ctx.ScopeAnnotation: ctx.ScopeReadProject},
+		Run:         importAction,
+	}
+	importCmd.Flags().Int("project", 0, "Number of the project to import views from")
+	importCmd.Flags().String("owner", "", "Login of the project owner (defaults to you)")
+	importCmd.Flags().String("prefix", "", "Prefix added to the names of the imported views")
+	importCmd.MarkFlagRequired("project")
+
+	subCommands := []*cobra.Command{
+		saveCmd,
+		openCmd,
+		{
+			Use:   "list",
+			Short: "List saved views",
+			Run:   listAction,
+		},
+		{
+			Use:   "delete <name>",
+			Short: "Delete a saved view",
+			Args:  cobra.ExactArgs(1),
+			Run:   deleteAction,
+		},
+		importCmd,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Manage saved project views",
+		Run:   listAction,
+	}
+	cmd.AddCommand(subCommands...)
+	return cmd
+}